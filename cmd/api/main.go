@@ -6,6 +6,7 @@ import (
 	_ "github.com/ghduuep/pingly/docs"
 	"github.com/ghduuep/pingly/internal/api"
 	"github.com/ghduuep/pingly/internal/database"
+	"github.com/ghduuep/pingly/internal/monitor"
 	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -41,6 +42,8 @@ func main() {
 	rdb := database.InitRedis()
 	defer rdb.Close()
 
+	monitor.RegisterHeartbeat(db)
+
 	e := echo.New()
 
 	e.Use(middleware.Logger())
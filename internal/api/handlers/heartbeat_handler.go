@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ghduuep/pingly/internal/database"
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+const maxHeartbeatMessage = 1024
+
+// @Summary Send a heartbeat
+// @Description Signal that a job ran successfully. Use /start before a run and /fail to report a failure.
+// @Tags heartbeats
+// @Param token path string true "Heartbeat token"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /heartbeat/{token} [post]
+func (h *Handler) Heartbeat(c echo.Context) error {
+	return h.recordHeartbeat(c, models.HeartbeatPing)
+}
+
+// @Summary Signal a job start
+// @Tags heartbeats
+// @Param token path string true "Heartbeat token"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /heartbeat/{token}/start [post]
+func (h *Handler) HeartbeatStart(c echo.Context) error {
+	return h.recordHeartbeat(c, models.HeartbeatStart)
+}
+
+// @Summary Report a job failure
+// @Description The request body, if any, is stored as the failure message.
+// @Tags heartbeats
+// @Param token path string true "Heartbeat token"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /heartbeat/{token}/fail [post]
+func (h *Handler) HeartbeatFail(c echo.Context) error {
+	return h.recordHeartbeat(c, models.HeartbeatFail)
+}
+
+func (h *Handler) recordHeartbeat(c echo.Context, event models.HeartbeatEvent) error {
+	token := c.Param("token")
+
+	var message string
+	if c.Request().Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(c.Request().Body, maxHeartbeatMessage))
+		message = strings.TrimSpace(string(body))
+	}
+
+	found, err := database.RecordHeartbeatEvent(c.Request().Context(), h.DB, token, event, message)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to record heartbeat."})
+	}
+
+	if !found {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "Heartbeat not found."})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func generateHeartbeatToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func heartbeatURL(token string) string {
+	return "/api/v1/heartbeat/" + token
+}
@@ -3,14 +3,17 @@ package handlers
 import (
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"github.com/ghduuep/pingly/internal/database"
 	"github.com/ghduuep/pingly/internal/dto"
 	"github.com/ghduuep/pingly/internal/models"
+	"github.com/ghduuep/pingly/internal/monitor"
 	"github.com/labstack/echo/v4"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -44,7 +47,7 @@ func (h *Handler) GetMonitors(c echo.Context) error {
 			UserID:           m.UserID,
 			Target:           m.Target,
 			Type:             m.Type,
-			Config:           m.Config,
+			Config:           redactConfig(m.Config),
 			Interval:         m.Interval,
 			Timeout:          m.Timeout,
 			LatencyThreshold: m.LatencyThreshold,
@@ -101,7 +104,7 @@ func (h *Handler) GetMonitorByID(c echo.Context) error {
 		UserID:           monitor.UserID,
 		Target:           monitor.Target,
 		Type:             monitor.Type,
-		Config:           monitor.Config,
+		Config:           redactConfig(monitor.Config),
 		Interval:         monitor.Interval,
 		Timeout:          monitor.Timeout,
 		LatencyThreshold: monitor.LatencyThreshold,
@@ -110,6 +113,13 @@ func (h *Handler) GetMonitorByID(c echo.Context) error {
 		StatusChangedAt:  monitor.StatusChangedAt,
 	}
 
+	if monitor.Type == models.TypeHeartbeat {
+		heartbeat, err := database.GetHeartbeatByMonitorID(c.Request().Context(), h.DB, monitor.ID)
+		if err == nil {
+			dto.HeartbeatURL = heartbeatURL(heartbeat.Token)
+		}
+	}
+
 	return c.JSON(http.StatusOK, dto)
 }
 
@@ -134,6 +144,10 @@ func (h *Handler) CreateMonitor(c echo.Context) error {
 		return err
 	}
 
+	if err := validateMonitorConfig(req.Type, req.Config); err != nil {
+		return err
+	}
+
 	userID := getUserIdFromToken(c)
 
 	intervalDuration, _ := time.ParseDuration(req.Interval)
@@ -154,6 +168,18 @@ func (h *Handler) CreateMonitor(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Monitor already exists."})
 	}
 
+	if monitor.Type == models.TypeHeartbeat {
+		token, err := generateHeartbeatToken()
+		if err == nil {
+			err = database.CreateHeartbeat(c.Request().Context(), h.DB, monitor.ID, token)
+		}
+
+		if err != nil {
+			_ = database.DeleteMonitor(c.Request().Context(), h.DB, monitor.ID, userID)
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create heartbeat."})
+		}
+	}
+
 	return c.NoContent(http.StatusCreated)
 }
 
@@ -432,6 +458,18 @@ func (h *Handler) ExportMonitorCSV(c echo.Context) error {
 			} else {
 				displayValue = "Connected"
 			}
+		case models.TypeTLS, models.TypeDomain:
+			if resultVal != nil {
+				displayValue = fmt.Sprintf("%s days", *resultVal)
+			} else {
+				displayValue = "N/A"
+			}
+		default:
+			if resultVal != nil {
+				displayValue = *resultVal
+			} else {
+				displayValue = "N/A"
+			}
 		}
 
 		writer.Write([]string{
@@ -476,6 +514,26 @@ func (h *Handler) UpdateMonitor(c echo.Context) error {
 
 	userID := getUserIdFromToken(c)
 
+	if req.Config != nil || req.Target != nil {
+		existing, err := database.GetMonitorByIDAndUser(c.Request().Context(), h.DB, id, userID)
+		if err != nil {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Monitor not found."})
+		}
+
+		// Heartbeats are pushed to, so only they may go without a target.
+		if req.Target != nil && strings.TrimSpace(*req.Target) == "" && existing.Type != models.TypeHeartbeat {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "Target is required."})
+		}
+
+		if req.Config != nil {
+			req.Config = restoreRedactedConfig(req.Config, existing.Config)
+
+			if err := validateMonitorConfig(existing.Type, req.Config); err != nil {
+				return err
+			}
+		}
+	}
+
 	err = database.UpdateMonitor(c.Request().Context(), h.DB, id, userID, req, intervalDuration, timeoutDuration)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update monitor."})
@@ -494,3 +552,18 @@ func (h *Handler) GetMonitorsSummary(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, summary)
 }
+
+// validateMonitorConfig checks that the type is registered and that its
+// checker accepts the config.
+func validateMonitorConfig(monitorType models.MonitorType, config json.RawMessage) error {
+	checker, ok := monitor.Lookup(monitorType)
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("Unsupported monitor type '%s'.", monitorType)})
+	}
+
+	if err := checker.ValidateConfig(config); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return nil
+}
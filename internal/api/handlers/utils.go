@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
@@ -29,3 +31,97 @@ func getPaginationParams(c echo.Context) (int, int, int) {
 	offset := (page - 1) * limit
 	return page, limit, offset
 }
+
+const redactedValue = "********"
+
+var secretConfigKeys = map[string]bool{
+	"password": true,
+	"token":    true,
+	"secret":   true,
+}
+
+var secretHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"x-api-key":           true,
+}
+
+// redactConfig masks credentials stored in a monitor config so they are never
+// echoed back by the API.
+func redactConfig(config json.RawMessage) json.RawMessage {
+	var doc any
+	if len(config) == 0 || json.Unmarshal(config, &doc) != nil {
+		return config
+	}
+
+	redacted, err := json.Marshal(redactValue(doc, false))
+	if err != nil {
+		return config
+	}
+
+	return redacted
+}
+
+func redactValue(value any, inHeaders bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			lower := strings.ToLower(key)
+
+			if _, isString := child.(string); isString && child != "" && (secretConfigKeys[lower] || (inHeaders && secretHeaders[lower])) {
+				v[key] = redactedValue
+				continue
+			}
+
+			v[key] = redactValue(child, lower == "headers" || lower == "metadata")
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child, false)
+		}
+	}
+
+	return value
+}
+
+// restoreRedactedConfig puts back the stored secrets wherever an updated
+// config still carries the redaction mask returned by the API.
+func restoreRedactedConfig(incoming, stored json.RawMessage) json.RawMessage {
+	var newDoc, oldDoc any
+	if json.Unmarshal(incoming, &newDoc) != nil || json.Unmarshal(stored, &oldDoc) != nil {
+		return incoming
+	}
+
+	restored, err := json.Marshal(restoreValue(newDoc, oldDoc))
+	if err != nil {
+		return incoming
+	}
+
+	return restored
+}
+
+func restoreValue(incoming, stored any) any {
+	if incoming == redactedValue {
+		return stored
+	}
+
+	switch v := incoming.(type) {
+	case map[string]any:
+		old, _ := stored.(map[string]any)
+		for key, child := range v {
+			v[key] = restoreValue(child, old[key])
+		}
+	case []any:
+		old, _ := stored.([]any)
+		for i, child := range v {
+			var oldChild any
+			if i < len(old) {
+				oldChild = old[i]
+			}
+			v[i] = restoreValue(child, oldChild)
+		}
+	}
+
+	return incoming
+}
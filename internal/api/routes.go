@@ -20,6 +20,13 @@ func SetupRotes(e *echo.Echo, db *pgxpool.Pool, rdb *redis.Client) {
 
 	v1.POST("/register", handler.Register)
 	v1.POST("/login", handler.Login)
+
+	v1.GET("/heartbeat/:token", handler.Heartbeat)
+	v1.POST("/heartbeat/:token", handler.Heartbeat)
+	v1.GET("/heartbeat/:token/start", handler.HeartbeatStart)
+	v1.POST("/heartbeat/:token/start", handler.HeartbeatStart)
+	v1.GET("/heartbeat/:token/fail", handler.HeartbeatFail)
+	v1.POST("/heartbeat/:token/fail", handler.HeartbeatFail)
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	protected := e.Group("/api/v1")
@@ -11,8 +11,8 @@ import (
 )
 
 func CreateCheckResult(ctx context.Context, db *pgxpool.Pool, result *models.CheckResult) error {
-	query := `INSERT INTO check_results (monitor_id, status, latency_ms, status_code, result_value, message, checked_at) VALUES ($1, $2, $3, $4, $5, $6, NOW()) RETURNING id`
-	err := db.QueryRow(ctx, query, result.MonitorID, result.Status, result.Latency, result.StatusCode, result.ResultValue, result.Message).Scan(&result.ID)
+	query := `INSERT INTO check_results (monitor_id, status, latency_ms, status_code, result_value, message, details, checked_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NOW()) RETURNING id`
+	err := db.QueryRow(ctx, query, result.MonitorID, result.Status, result.Latency, result.StatusCode, result.ResultValue, result.Message, result.Details).Scan(&result.ID)
 	if err != nil {
 		return err
 	}
@@ -70,7 +70,7 @@ func GetMonitorStats(ctx context.Context, db *pgxpool.Pool, monitorID int, thres
 
 func GetLastChecks(ctx context.Context, db *pgxpool.Pool, monitorID int, from, to time.Time) ([]*models.CheckResult, error) {
 	query := `
-	SELECT id, monitor_id, status, result_value, message, status_code, latency_ms, checked_at
+	SELECT id, monitor_id, status, result_value, message, status_code, latency_ms, details, checked_at
 	FROM check_results
 	WHERE monitor_id = $1
 	AND checked_at >= $2 AND checked_at <= $3
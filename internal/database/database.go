@@ -59,7 +59,7 @@ func createTables(ctx context.Context, pool *pgxpool.Pool) error {
 		id SERIAL PRIMARY KEY,
 		user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
 		target TEXT NOT NULL,
-		type VARCHAR(10) NOT NULL,
+		type VARCHAR(20) NOT NULL,
 		config JSONB DEFAULT '{}'::jsonb,
 		interval INTERVAL NOT NULL,
 		timeout INTERVAL NOT NULL DEFAULT INTERVAL '30 seconds',
@@ -70,6 +70,7 @@ func createTables(ctx context.Context, pool *pgxpool.Pool) error {
 		created_at TIMESTAMPTZ DEFAULT NOW()
 	);
 	CREATE INDEX IF NOT EXISTS idx_monitors_user_id ON monitors(user_id);
+	ALTER TABLE monitors ALTER COLUMN type TYPE VARCHAR(20);
 
 	CREATE TABLE IF NOT EXISTS incidents (
 		id SERIAL PRIMARY KEY,
@@ -80,6 +81,17 @@ func createTables(ctx context.Context, pool *pgxpool.Pool) error {
 		error_cause TEXT
 	);
 	CREATE INDEX IF NOT EXISTS idx_incidents_monitor_id ON incidents(monitor_id);
+
+	CREATE TABLE IF NOT EXISTS heartbeats (
+		monitor_id INTEGER PRIMARY KEY REFERENCES monitors(id) ON DELETE CASCADE,
+		token TEXT UNIQUE NOT NULL,
+		last_ping_at TIMESTAMPTZ,
+		last_start_at TIMESTAMPTZ,
+		last_event VARCHAR(10) NOT NULL DEFAULT '',
+		last_event_at TIMESTAMPTZ,
+		last_message TEXT NOT NULL DEFAULT ''
+	);
+	ALTER TABLE heartbeats ADD COLUMN IF NOT EXISTS last_duration_ms BIGINT;
 	`
 	if _, err := pool.Exec(ctx, queryStandard); err != nil {
 		return err
@@ -96,8 +108,11 @@ func createTables(ctx context.Context, pool *pgxpool.Pool) error {
 		message TEXT,
 		status_code INTEGER,
 		latency_ms INTEGER,
+		details JSONB,
 		checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 		);
+
+	ALTER TABLE check_results ADD COLUMN IF NOT EXISTS details JSONB;
 	`
 	if _, err := pool.Exec(ctx, queryTimescaleBase); err != nil {
 		return err
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateHeartbeat(ctx context.Context, db *pgxpool.Pool, monitorID int, token string) error {
+	query := `INSERT INTO heartbeats (monitor_id, token) VALUES ($1, $2)`
+	_, err := db.Exec(ctx, query, monitorID, token)
+	return err
+}
+
+func GetHeartbeatByMonitorID(ctx context.Context, db *pgxpool.Pool, monitorID int) (*models.Heartbeat, error) {
+	query := `SELECT monitor_id, token, last_ping_at, last_start_at, last_event, last_event_at, last_message, last_duration_ms FROM heartbeats WHERE monitor_id = $1`
+
+	rows, err := db.Query(ctx, query, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	heartbeat, err := pgx.CollectExactlyOneRow(rows, pgx.RowToAddrOfStructByName[models.Heartbeat])
+	if err != nil {
+		return nil, err
+	}
+
+	return heartbeat, nil
+}
+
+// RecordHeartbeatEvent stores a ping sent to a heartbeat URL and reports
+// whether the token belongs to a monitor. A ping that follows a start records
+// how long the run took; any other ping clears it, as the start belongs to an
+// earlier run.
+func RecordHeartbeatEvent(ctx context.Context, db *pgxpool.Pool, token string, event models.HeartbeatEvent, message string) (bool, error) {
+	query := `
+	UPDATE heartbeats SET
+		last_ping_at = CASE WHEN $2 = 'ping' THEN NOW() ELSE last_ping_at END,
+		last_start_at = CASE WHEN $2 = 'start' THEN NOW() ELSE last_start_at END,
+		last_duration_ms = CASE
+			WHEN $2 <> 'ping' THEN last_duration_ms
+			WHEN last_event = 'start' THEN (EXTRACT(EPOCH FROM NOW() - last_start_at) * 1000)::BIGINT
+			ELSE NULL
+		END,
+		last_event = $2,
+		last_event_at = NOW(),
+		last_message = $3
+	WHERE token = $1
+	`
+
+	tag, err := db.Exec(ctx, query, token, string(event), message)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
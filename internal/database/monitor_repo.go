@@ -124,6 +124,18 @@ func SetInitialDNSConfig(ctx context.Context, db *pgxpool.Pool, monitorID int, d
 	return err
 }
 
+// SetInitialSSHHostKey pins the fingerprint and key algorithm, keeping any
+// value the user configured.
+func SetInitialSSHHostKey(ctx context.Context, db *pgxpool.Pool, monitorID int, fingerprint, algorithm string) error {
+	query := `UPDATE monitors SET config = COALESCE(config, '{}'::jsonb) || jsonb_build_object(
+		'fingerprint', COALESCE(NULLIF(config->>'fingerprint', ''), $1::text),
+		'host_key_algorithm', COALESCE(NULLIF(config->>'host_key_algorithm', ''), $2::text))
+	WHERE id = $3`
+
+	_, err := db.Exec(ctx, query, fingerprint, algorithm, monitorID)
+	return err
+}
+
 func GetMonitorByIDAndUser(ctx context.Context, db *pgxpool.Pool, monitorID int, userID int) (models.Monitor, error) {
 	query := `SELECT * FROM monitors WHERE id = $1 AND user_id = $2`
 
@@ -25,8 +25,8 @@ type LoginRequest struct {
 }
 
 type MonitorRequest struct {
-	Target           string             `json:"target" db:"target" validate:"required"`
-	Type             models.MonitorType `json:"type" db:"type" validate:"required,oneof=http dns port"`
+	Target           string             `json:"target" db:"target" validate:"required_unless=Type heartbeat"`
+	Type             models.MonitorType `json:"type" db:"type" validate:"required"`
 	Config           json.RawMessage    `json:"config" db:"config" swaggertype:"string"`
 	Interval         string             `json:"interval" validate:"required,oneof=30s 1m 5m 30m 1h 12h 24h"`
 	Timeout          string             `json:"timeout" validate:"required,oneof=1s 15s 30s 45s 60s"`
@@ -45,6 +45,7 @@ type MonitorResponse struct {
 	LastCheckStatus  models.MonitorStatus `json:"last_check_status" db:"last_check_status"`
 	LastCheckAt      *time.Time           `json:"last_check_at" db:"last_check_at"`
 	StatusChangedAt  *time.Time           `json:"status_changed_at" db:"status_changed_at"`
+	HeartbeatURL     string               `json:"heartbeat_url,omitempty"`
 }
 
 type MonitorStatsResponse struct {
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/go-playground/validator/v10"
+)
+
+func TestMonitorRequestTarget(t *testing.T) {
+	v := validator.New()
+
+	tests := []struct {
+		name    string
+		req     MonitorRequest
+		wantErr bool
+	}{
+		{name: "heartbeat without target", req: MonitorRequest{Type: models.TypeHeartbeat, Interval: "1h", Timeout: "30s"}},
+		{name: "http without target", req: MonitorRequest{Type: models.TypeHTTP, Interval: "1h", Timeout: "30s"}, wantErr: true},
+		{name: "http with target", req: MonitorRequest{Type: models.TypeHTTP, Target: "https://example.com", Interval: "1h", Timeout: "30s"}},
+	}
+
+	for _, tt := range tests {
+		if err := v.Struct(tt.req); (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
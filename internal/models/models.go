@@ -36,6 +36,26 @@ const (
 	TypeHTTP MonitorType = "http"
 	TypePort MonitorType = "port"
 	TypeDNS  MonitorType = "dns"
+	TypeTLS  MonitorType = "tls"
+	TypeICMP MonitorType = "icmp"
+	TypeUDP  MonitorType = "udp"
+
+	TypeHeartbeat   MonitorType = "heartbeat"
+	TypeDomain      MonitorType = "domain"
+	TypePostgres    MonitorType = "postgres"
+	TypeRedis       MonitorType = "redis"
+	TypeMySQL       MonitorType = "mysql"
+	TypeSMTP        MonitorType = "smtp"
+	TypeIMAP        MonitorType = "imap"
+	TypePOP3        MonitorType = "pop3"
+	TypeGRPC        MonitorType = "grpc"
+	TypeWebSocket   MonitorType = "websocket"
+	TypeTransaction MonitorType = "transaction"
+	TypeContent     MonitorType = "content"
+	TypePrometheus  MonitorType = "prometheus"
+	TypeSSH         MonitorType = "ssh"
+	TypeNTP         MonitorType = "ntp"
+	TypeDNSBL       MonitorType = "dnsbl"
 )
 
 type MonitorStatus string
@@ -63,23 +83,415 @@ type Monitor struct {
 }
 
 type CheckResult struct {
-	ID          int           `json:"id" db:"id"`
-	MonitorID   int           `json:"monitor_id" db:"monitor_id"`
-	Status      MonitorStatus `json:"status" db:"status"`
-	Latency     int64         `json:"latency_ms,omitempty" db:"latency_ms"`
-	StatusCode  int           `json:"status_code,omitempty" db:"status_code"`
-	ResultValue string        `json:"result_value,omitempty" db:"result_value"`
-	Message     string        `json:"message,omitempty" db:"message"`
-	CheckedAt   time.Time     `json:"checked_at" db:"checked_at"`
+	ID          int             `json:"id" db:"id"`
+	MonitorID   int             `json:"monitor_id" db:"monitor_id"`
+	Status      MonitorStatus   `json:"status" db:"status"`
+	Latency     int64           `json:"latency_ms,omitempty" db:"latency_ms"`
+	StatusCode  int             `json:"status_code,omitempty" db:"status_code"`
+	ResultValue string          `json:"result_value,omitempty" db:"result_value"`
+	Message     string          `json:"message,omitempty" db:"message"`
+	Details     json.RawMessage `json:"details,omitempty" db:"details" swaggertype:"object"`
+	CheckedAt   time.Time       `json:"checked_at" db:"checked_at"`
 }
 
 type DNSConfig struct {
-	RecordType    string `json:"record_type"`
-	ExpectedValue string `json:"expected_value"`
+	RecordType    string   `json:"record_type"`
+	ExpectedValue string   `json:"expected_value"`
+	Resolvers     []string `json:"resolvers,omitempty"`
+	Policy        string   `json:"policy,omitempty"`
+	SerialMaxAge  string   `json:"serial_max_age,omitempty"`
+	Mode          string   `json:"mode,omitempty"`
+
+	// Deliverability mode: DKIM selectors to check and the weakest SPF
+	// "all" qualifier and DMARC policy that are still acceptable.
+	DKIMSelectors []string `json:"dkim_selectors,omitempty"`
+	SPFAll        string   `json:"spf_all,omitempty"`
+	DMARCPolicy   string   `json:"dmarc_policy,omitempty"`
+}
+
+type DNSDetails struct {
+	Mode    string           `json:"mode,omitempty"`
+	Zone    string           `json:"zone,omitempty"`
+	Policy  string           `json:"policy,omitempty"`
+	Answers []ResolverAnswer `json:"answers"`
+}
+
+type DeliverabilityDetails struct {
+	Mode  string        `json:"mode"`
+	SPF   SPFDetails    `json:"spf"`
+	DMARC DMARCDetails  `json:"dmarc"`
+	DKIM  []DKIMDetails `json:"dkim,omitempty"`
+}
+
+type SPFDetails struct {
+	Record  string `json:"record,omitempty"`
+	All     string `json:"all,omitempty"`
+	Lookups int    `json:"lookups"`
+	Error   string `json:"error,omitempty"`
+}
+
+type DMARCDetails struct {
+	Record               string `json:"record,omitempty"`
+	Policy               string `json:"policy,omitempty"`
+	SubdomainPolicy      string `json:"subdomain_policy,omitempty"`
+	Percent              int    `json:"pct"`
+	OrganizationalDomain string `json:"organizational_domain,omitempty"`
+	Error                string `json:"error,omitempty"`
+}
+
+type DKIMDetails struct {
+	Selector string `json:"selector"`
+	KeyType  string `json:"key_type,omitempty"`
+	KeyBits  int    `json:"key_bits,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type ResolverAnswer struct {
+	Resolver string `json:"resolver"`
+	Value    string `json:"value,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Matches  bool   `json:"matches"`
+	Latency  int64  `json:"latency_ms"`
 }
 
 type HTTPConfig struct {
-	CheckSSL bool `json:"check_ssl"`
+	CheckSSL   bool              `json:"check_ssl"`
+	Method     string            `json:"method,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	Auth       *HTTPAuth         `json:"auth,omitempty"`
+	Assertions []HTTPAssertion   `json:"assertions,omitempty"`
+
+	AcceptedStatusCodes string `json:"accepted_status_codes,omitempty"`
+	FollowRedirects     *bool  `json:"follow_redirects,omitempty"`
+	MaxRedirects        int    `json:"max_redirects,omitempty"`
+}
+
+type HTTPAuthType string
+
+const (
+	AuthBasic  HTTPAuthType = "basic"
+	AuthBearer HTTPAuthType = "bearer"
+)
+
+type HTTPAuth struct {
+	Type     HTTPAuthType `json:"type"`
+	Username string       `json:"username,omitempty"`
+	Password string       `json:"password,omitempty"`
+	Token    string       `json:"token,omitempty"`
+}
+
+type AssertionType string
+
+const (
+	AssertContains    AssertionType = "contains"
+	AssertNotContains AssertionType = "not_contains"
+	AssertRegex       AssertionType = "regex"
+	AssertJSONPath    AssertionType = "json_path"
+)
+
+type HTTPAssertion struct {
+	Type     AssertionType `json:"type"`
+	Path     string        `json:"path,omitempty"`
+	Operator string        `json:"operator,omitempty"`
+	Value    string        `json:"value"`
+}
+
+type TLSConfig struct {
+	StartTLS   string `json:"starttls,omitempty"`
+	ServerName string `json:"server_name,omitempty"`
+}
+
+type TLSDetails struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	SANs          []string  `json:"sans,omitempty"`
+	KeyType       string    `json:"key_type"`
+	TLSVersion    string    `json:"tls_version"`
+	NotAfter      time.Time `json:"not_after"`
+	DaysRemaining int       `json:"days_remaining"`
+	ChainError    string    `json:"chain_error,omitempty"`
+}
+
+type PingConfig struct {
+	Count        int     `json:"count,omitempty"`
+	DegradedLoss float64 `json:"degraded_loss_percent,omitempty"`
+}
+
+type PingDetails struct {
+	Sent       int     `json:"sent"`
+	Received   int     `json:"received"`
+	PacketLoss float64 `json:"packet_loss_percent"`
+	MinRTT     float64 `json:"min_rtt_ms"`
+	AvgRTT     float64 `json:"avg_rtt_ms"`
+	MaxRTT     float64 `json:"max_rtt_ms"`
+	Jitter     float64 `json:"jitter_ms"`
+}
+
+type UDPConfig struct {
+	Payload       string `json:"payload,omitempty"`
+	PayloadFormat string `json:"payload_format,omitempty"`
+	Expect        string `json:"expect,omitempty"`
+	ExpectFormat  string `json:"expect_format,omitempty"`
+}
+
+type UDPDetails struct {
+	Bytes        int    `json:"bytes"`
+	ResponseHex  string `json:"response_hex"`
+	ResponseText string `json:"response_text,omitempty"`
+	RTT          int64  `json:"rtt_ms"`
+}
+
+type DatabaseConfig struct {
+	Username      string `json:"username"`
+	Password      string `json:"password,omitempty"`
+	Database      string `json:"database,omitempty"`
+	Query         string `json:"query,omitempty"`
+	ExpectedValue string `json:"expected_value,omitempty"`
+	SSLMode       string `json:"ssl_mode,omitempty"`
+}
+
+type RedisConfig struct {
+	Username     string `json:"username,omitempty"`
+	Password     string `json:"password,omitempty"`
+	DB           int    `json:"db,omitempty"`
+	TLS          bool   `json:"tls,omitempty"`
+	ExpectedRole string `json:"expected_role,omitempty"`
+}
+
+type DatabaseDetails struct {
+	ServerVersion string `json:"server_version,omitempty"`
+	Role          string `json:"role,omitempty"`
+	ConnectTime   int64  `json:"connect_ms"`
+	QueryTime     int64  `json:"query_ms"`
+}
+
+type MailConfig struct {
+	TLS            bool     `json:"tls,omitempty"`
+	StartTLS       bool     `json:"starttls,omitempty"`
+	BannerContains string   `json:"banner_contains,omitempty"`
+	AuthMechanisms []string `json:"auth_mechanisms,omitempty"`
+}
+
+type MailDetails struct {
+	Banner         string   `json:"banner"`
+	Capabilities   []string `json:"capabilities,omitempty"`
+	AuthMechanisms []string `json:"auth_mechanisms,omitempty"`
+	TLSVersion     string   `json:"tls_version,omitempty"`
+}
+
+type GRPCConfig struct {
+	Service            string            `json:"service,omitempty"`
+	TLS                bool              `json:"tls,omitempty"`
+	ServerName         string            `json:"server_name,omitempty"`
+	InsecureSkipVerify bool              `json:"insecure_skip_verify,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+type GRPCDetails struct {
+	ServingStatus string `json:"serving_status,omitempty"`
+	GRPCStatus    int    `json:"grpc_status"`
+	GRPCMessage   string `json:"grpc_message,omitempty"`
+}
+
+type WebSocketConfig struct {
+	Headers     map[string]string `json:"headers,omitempty"`
+	Origin      string            `json:"origin,omitempty"`
+	Subprotocol string            `json:"subprotocol,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Expect      string            `json:"expect,omitempty"`
+}
+
+type WebSocketDetails struct {
+	HandshakeTime int64  `json:"handshake_ms"`
+	RoundTrip     int64  `json:"round_trip_ms,omitempty"`
+	Response      string `json:"response,omitempty"`
+}
+
+type ExtractionSource string
+
+const (
+	ExtractJSONPath ExtractionSource = "json_path"
+	ExtractHeader   ExtractionSource = "header"
+	ExtractRegex    ExtractionSource = "regex"
+)
+
+// Extraction stores part of a step's response in a variable that later
+// steps reference as {{name}}.
+type Extraction struct {
+	Name       string           `json:"name"`
+	Source     ExtractionSource `json:"source"`
+	Expression string           `json:"expression"`
+}
+
+type TransactionStep struct {
+	Name    string       `json:"name,omitempty"`
+	URL     string       `json:"url,omitempty"`
+	Extract []Extraction `json:"extract,omitempty"`
+	HTTPConfig
+}
+
+type TransactionConfig struct {
+	Steps     []TransactionStep `json:"steps"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+type StepResult struct {
+	Name       string      `json:"name,omitempty"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code,omitempty"`
+	Duration   int64       `json:"duration_ms"`
+	Timing     *HTTPTiming `json:"timing,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+type TransactionDetails struct {
+	Steps      []StepResult `json:"steps"`
+	FailedStep *int         `json:"failed_step,omitempty"`
+}
+
+// ContentConfig narrows the page before it is hashed. Selector takes
+// precedence over Pattern; IgnorePatterns are stripped from what is left.
+type ContentConfig struct {
+	Selector       string   `json:"selector,omitempty"`
+	Pattern        string   `json:"pattern,omitempty"`
+	IgnorePatterns []string `json:"ignore_patterns,omitempty"`
+	HTTPConfig
+}
+
+type ContentDetails struct {
+	Hash     string `json:"hash"`
+	Size     int    `json:"size"`
+	Snapshot string `json:"snapshot,omitempty"`
+	Added    int    `json:"added_lines,omitempty"`
+	Removed  int    `json:"removed_lines,omitempty"`
+	Diff     string `json:"diff,omitempty"`
+}
+
+// PrometheusConfig picks one series from a text-format /metrics endpoint.
+// The value is compared with Operator against the thresholds, e.g. "> 1000"
+// for degraded and "> 5000" for down.
+type PrometheusConfig struct {
+	Metric            string            `json:"metric"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Aggregate         string            `json:"aggregate,omitempty"`
+	Operator          string            `json:"operator,omitempty"`
+	DegradedThreshold *float64          `json:"degraded_threshold,omitempty"`
+	DownThreshold     *float64          `json:"down_threshold,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
+	Auth              *HTTPAuth         `json:"auth,omitempty"`
+}
+
+type PrometheusDetails struct {
+	Series  []string `json:"series"`
+	Value   string   `json:"value"`
+	Matched int      `json:"matched"`
+}
+
+// SSHConfig pins the server host key. An empty Fingerprint is learned from
+// the first successful check.
+type SSHConfig struct {
+	Fingerprint      string `json:"fingerprint,omitempty"`
+	HostKeyAlgorithm string `json:"host_key_algorithm,omitempty"`
+}
+
+type SSHDetails struct {
+	Banner        string `json:"banner,omitempty"`
+	KeyType       string `json:"key_type,omitempty"`
+	Fingerprint   string `json:"fingerprint,omitempty"`
+	HandshakeTime int64  `json:"handshake_ms"`
+}
+
+type NTPConfig struct {
+	DegradedOffset float64 `json:"degraded_offset_ms,omitempty"`
+	DownOffset     float64 `json:"down_offset_ms,omitempty"`
+}
+
+type NTPDetails struct {
+	Stratum        int     `json:"stratum"`
+	RefID          string  `json:"ref_id,omitempty"`
+	LeapIndicator  int     `json:"leap_indicator"`
+	Offset         float64 `json:"offset_ms"`
+	Delay          float64 `json:"delay_ms"`
+	RootDelay      float64 `json:"root_delay_ms"`
+	RootDispersion float64 `json:"root_dispersion_ms"`
+}
+
+type DNSBLConfig struct {
+	Zones    []string `json:"zones,omitempty"`
+	Resolver string   `json:"resolver,omitempty"`
+}
+
+type DNSBLDetails struct {
+	Addresses []string      `json:"addresses"`
+	Zones     []string      `json:"zones"`
+	Results   []DNSBLResult `json:"results"`
+}
+
+type DNSBLResult struct {
+	Address string   `json:"address"`
+	Zone    string   `json:"zone"`
+	Listed  bool     `json:"listed"`
+	Codes   []string `json:"codes,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type HTTPDetails struct {
+	RedirectChain []RedirectHop `json:"redirect_chain,omitempty"`
+	Timing        *HTTPTiming   `json:"timing,omitempty"`
+}
+
+type HTTPTiming struct {
+	DNSLookup    int64 `json:"dns_lookup_ms"`
+	TCPConnect   int64 `json:"tcp_connect_ms"`
+	TLSHandshake int64 `json:"tls_handshake_ms"`
+	TTFB         int64 `json:"ttfb_ms"`
+	Transfer     int64 `json:"transfer_ms"`
+}
+
+type RedirectHop struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+	Location   string `json:"location,omitempty"`
+}
+
+type HeartbeatConfig struct {
+	GracePeriod string `json:"grace_period,omitempty"`
+}
+
+type HeartbeatEvent string
+
+const (
+	HeartbeatPing  HeartbeatEvent = "ping"
+	HeartbeatStart HeartbeatEvent = "start"
+	HeartbeatFail  HeartbeatEvent = "fail"
+)
+
+type Heartbeat struct {
+	MonitorID   int            `json:"monitor_id" db:"monitor_id"`
+	Token       string         `json:"token" db:"token"`
+	LastPingAt  *time.Time     `json:"last_ping_at" db:"last_ping_at"`
+	LastStartAt *time.Time     `json:"last_start_at" db:"last_start_at"`
+	LastEvent   HeartbeatEvent `json:"last_event" db:"last_event"`
+	LastEventAt *time.Time     `json:"last_event_at" db:"last_event_at"`
+	LastMessage string         `json:"last_message" db:"last_message"`
+
+	// LastDurationMs is how long the last run took, known only when its
+	// ping followed a start.
+	LastDurationMs *int64 `json:"last_duration_ms" db:"last_duration_ms"`
+}
+
+type DomainConfig struct {
+	RDAPURL string `json:"rdap_url,omitempty"`
+}
+
+type DomainDetails struct {
+	Registrar     string    `json:"registrar,omitempty"`
+	Status        []string  `json:"status,omitempty"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	DaysRemaining int       `json:"days_remaining"`
+	RDAPServer    string    `json:"rdap_server"`
 }
 
 type Incident struct {
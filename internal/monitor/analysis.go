@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ghduuep/pingly/internal/database"
@@ -48,6 +49,10 @@ func (m *MonitorManager) handleDNSLearning(ctx context.Context, mon *models.Moni
 
 	var config models.DNSConfig
 	if err := json.Unmarshal(mon.Config, &config); err == nil {
+		if config.RecordType == "SOA" || strings.EqualFold(config.Mode, DNSModeDeliverability) {
+			return
+		}
+
 		log.Printf("[INFO] Learning DNS value for monitor %d: %s", mon.ID, res.ResultValue)
 
 		if err := database.SetInitialDNSConfig(ctx, m.db, mon.ID, res.ResultValue); err != nil {
@@ -60,6 +65,47 @@ func (m *MonitorManager) handleDNSLearning(ctx context.Context, mon *models.Moni
 	}
 }
 
+// handleSSHLearning pins the host key seen on the first successful check when
+// no fingerprint was configured, together with its algorithm so a change in
+// the negotiated key type is not mistaken for a changed key.
+func (m *MonitorManager) handleSSHLearning(ctx context.Context, mon *models.Monitor, res *models.CheckResult) {
+	if mon.Type != models.TypeSSH || res.Status != models.StatusUp || res.ResultValue == "" {
+		return
+	}
+
+	var config models.SSHConfig
+	if len(mon.Config) > 0 {
+		if err := json.Unmarshal(mon.Config, &config); err != nil {
+			return
+		}
+	}
+
+	if config.Fingerprint != "" && config.HostKeyAlgorithm != "" {
+		return
+	}
+
+	var details models.SSHDetails
+	if err := json.Unmarshal(res.Details, &details); err != nil || details.KeyType == "" {
+		return
+	}
+
+	if config.Fingerprint == "" {
+		config.Fingerprint = res.ResultValue
+	}
+	if config.HostKeyAlgorithm == "" {
+		config.HostKeyAlgorithm = details.KeyType
+	}
+
+	log.Printf("[INFO] Pinning SSH host key for monitor %d: %s %s", mon.ID, config.HostKeyAlgorithm, config.Fingerprint)
+
+	if err := database.SetInitialSSHHostKey(ctx, m.db, mon.ID, config.Fingerprint, config.HostKeyAlgorithm); err != nil {
+		log.Printf("[ERROR] Failed to persist learned SSH host key: %v", err)
+	}
+
+	newJSON, _ := json.Marshal(config)
+	mon.Config = newJSON
+}
+
 func (m *MonitorManager) analyzePerformance(mon *models.Monitor, res *models.CheckResult) {
 	if res.Status != models.StatusUp {
 		return
@@ -74,21 +120,41 @@ func (m *MonitorManager) analyzePerformance(mon *models.Monitor, res *models.Che
 }
 
 func (m *MonitorManager) handleSSLAlerts(ctx context.Context, mon *models.Monitor, res *models.CheckResult) {
-	if mon.Type != models.TypeHTTP || res.ResultValue == "" {
+	if res.ResultValue == "" {
 		return
 	}
 
-	var config models.HTTPConfig
-	if err := json.Unmarshal(mon.Config, &config); err != nil || !config.CheckSSL {
+	switch mon.Type {
+	case models.TypeHTTP:
+		var config models.HTTPConfig
+		if err := json.Unmarshal(mon.Config, &config); err != nil || !config.CheckSSL {
+			return
+		}
+	case models.TypeTLS:
+	default:
+		return
+	}
+
+	m.stageExpiryAlert(ctx, mon, res, "ssl")
+}
+
+func (m *MonitorManager) handleDomainExpiry(ctx context.Context, mon *models.Monitor, res *models.CheckResult) {
+	if mon.Type != models.TypeDomain || res.ResultValue == "" {
 		return
 	}
 
+	m.stageExpiryAlert(ctx, mon, res, "domain")
+}
+
+// stageExpiryAlert sends one alert each time the days remaining in
+// res.ResultValue cross the 30, 14 and 7 day marks.
+func (m *MonitorManager) stageExpiryAlert(ctx context.Context, mon *models.Monitor, res *models.CheckResult, kind string) {
 	daysRemaining, err := strconv.Atoi(res.ResultValue)
 	if err != nil {
 		return
 	}
 
-	redisKey := fmt.Sprintf("monitor:%d:ssl_last_threshold", mon.ID)
+	redisKey := fmt.Sprintf("monitor:%d:%s_last_threshold", mon.ID, kind)
 
 	if daysRemaining > 30 {
 		m.redis.Del(ctx, redisKey)
@@ -100,7 +166,7 @@ func (m *MonitorManager) handleSSLAlerts(ctx context.Context, mon *models.Monito
 	if err == nil {
 		lastThreshold, _ = strconv.Atoi(lastThresholdStr)
 	} else if err != redis.Nil {
-		log.Printf("[ERROR] Redis error on SSL check: %v", err)
+		log.Printf("[ERROR] Redis error on %s expiry check: %v", kind, err)
 		return
 	}
 
@@ -119,15 +185,141 @@ func (m *MonitorManager) handleSSLAlerts(ctx context.Context, mon *models.Monito
 	}
 
 	if shouldAlert {
-		log.Printf("[INFO] Sending SSL periodic alert for monitor %d (Days: %d, Threshold: %d)", mon.ID, daysRemaining, currentMatchedThreshold)
+		log.Printf("[INFO] Sending %s expiry alert for monitor %d (Days: %d, Threshold: %d)", kind, mon.ID, daysRemaining, currentMatchedThreshold)
 
 		err := m.redis.Set(ctx, redisKey, currentMatchedThreshold, 60*24*time.Hour).Err()
 		if err != nil {
 			log.Printf("[ERROR] Failed to update redis key: %v", err)
 		}
 
-		channels, _ := database.GetEnabledUserChannels(ctx, m.db, mon.UserID)
+		m.notify(ctx, *mon, *res, nil)
+	}
+}
+
+func (m *MonitorManager) handleSOASerial(ctx context.Context, mon *models.Monitor, res *models.CheckResult) {
+	if mon.Type != models.TypeDNS || res.Status != models.StatusUp || res.ResultValue == "" {
+		return
+	}
+
+	var config models.DNSConfig
+	if err := json.Unmarshal(mon.Config, &config); err != nil || config.RecordType != "SOA" {
+		return
+	}
+
+	serial, err := strconv.ParseUint(res.ResultValue, 10, 32)
+	if err != nil {
+		return
+	}
+
+	serialKey := fmt.Sprintf("monitor:%d:soa_serial", mon.ID)
+	changedKey := fmt.Sprintf("monitor:%d:soa_changed_at", mon.ID)
+
+	lastSerialStr, err := m.redis.Get(ctx, serialKey).Result()
+	if err == redis.Nil {
+		m.redis.Set(ctx, serialKey, serial, 0)
+		m.redis.Set(ctx, changedKey, time.Now().Unix(), 0)
+		return
+	} else if err != nil {
+		log.Printf("[ERROR] Redis error on SOA check: %v", err)
+		return
+	}
+
+	lastSerial, _ := strconv.ParseUint(lastSerialStr, 10, 32)
+
+	switch {
+	case serialLess(uint32(serial), uint32(lastSerial)):
+		// A rollback is a one-off event: the alert goes out right away, as
+		// the failure threshold would swallow a single down result, and the
+		// new serial becomes the baseline.
+		res.Message = fmt.Sprintf("SOA serial went backwards (%d -> %d)", lastSerial, serial)
+		log.Printf("[INFO] SOA serial of monitor %d went backwards (%d -> %d)", mon.ID, lastSerial, serial)
+
+		m.redis.Set(ctx, serialKey, serial, 0)
+		m.redis.Set(ctx, changedKey, time.Now().Unix(), 0)
+
+		alert := *res
+		alert.Status = models.StatusDown
+		m.notify(ctx, *mon, alert, nil)
+		return
+
+	case serial != lastSerial:
+		m.redis.Set(ctx, serialKey, serial, 0)
+		m.redis.Set(ctx, changedKey, time.Now().Unix(), 0)
+		return
+	}
+
+	maxAge, err := time.ParseDuration(config.SerialMaxAge)
+	if err != nil || maxAge <= 0 {
+		return
+	}
+
+	changedAt, err := m.redis.Get(ctx, changedKey).Int64()
+	if err != nil {
+		return
+	}
+
+	if unchanged := time.Since(time.Unix(changedAt, 0)); unchanged > maxAge {
+		res.Status = models.StatusDegraded
+		res.Message = fmt.Sprintf("SOA serial %d unchanged for %s (Limit: %s)", serial, unchanged.Round(time.Minute), maxAge)
+	}
+}
+
+// handleContentChange keeps the last content snapshot in Redis and sends an
+// alert with a line diff when the hash changes. The first check only learns
+// the baseline, and a change does not mark the monitor down.
+func (m *MonitorManager) handleContentChange(ctx context.Context, mon *models.Monitor, res *models.CheckResult) {
+	if mon.Type != models.TypeContent || res.Status != models.StatusUp {
+		return
+	}
+
+	var details models.ContentDetails
+	if err := json.Unmarshal(res.Details, &details); err != nil || details.Hash == "" {
+		return
+	}
+
+	// The snapshot only travels to this point; it is kept in Redis, not in
+	// every stored check result.
+	snapshot := details.Snapshot
+	details.Snapshot = ""
+	defer func() { res.Details = marshalDetails(details) }()
+
+	hashKey := fmt.Sprintf("monitor:%d:content_hash", mon.ID)
+	snapshotKey := fmt.Sprintf("monitor:%d:content_snapshot", mon.ID)
+
+	lastHash, err := m.redis.Get(ctx, hashKey).Result()
+	if err == redis.Nil {
+		log.Printf("[INFO] Learning content baseline for monitor %d: %s", mon.ID, details.Hash)
+		m.redis.Set(ctx, hashKey, details.Hash, 0)
+		m.redis.Set(ctx, snapshotKey, snapshot, 0)
+		return
+	} else if err != nil {
+		log.Printf("[ERROR] Redis error on content check: %v", err)
+		return
+	}
+
+	if lastHash == details.Hash {
+		return
+	}
 
-		go m.dispatcher.SendAlert(channels, *mon, *res, nil)
+	previous, _ := m.redis.Get(ctx, snapshotKey).Result()
+	details.Diff, details.Added, details.Removed = diffLines(previous, snapshot)
+	if details.Diff == "" {
+		details.Diff = fmt.Sprintf("(change is past the first %d KB kept for diffs)", MaxContentSnapshot>>10)
 	}
+
+	m.redis.Set(ctx, hashKey, details.Hash, 0)
+	m.redis.Set(ctx, snapshotKey, snapshot, 0)
+
+	res.Message = fmt.Sprintf("Content changed (+%d/-%d lines)", details.Added, details.Removed)
+	log.Printf("[INFO] Content change detected for monitor %d (%s -> %s)", mon.ID, lastHash, details.Hash)
+
+	alert := *res
+	alert.Details = marshalDetails(details)
+	m.notify(ctx, *mon, alert, nil)
+}
+
+// serialLess compares zone serials using RFC 1982 serial number arithmetic
+// so a serial wrapping around 2^32 is not mistaken for going backwards.
+func serialLess(a, b uint32) bool {
+	return a != b && int32(a-b) < 0
 }
@@ -0,0 +1,97 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+type sentAlert struct {
+	res models.CheckResult
+	inc *models.Incident
+}
+
+// newTestManager returns a manager backed by an in-memory Redis that records
+// alerts instead of delivering them.
+func newTestManager(t *testing.T) (*MonitorManager, *[]sentAlert) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	var alerts []sentAlert
+	m := &MonitorManager{redis: rdb, activeMonitors: make(map[int]*activeMonitor)}
+	m.notify = func(_ context.Context, _ models.Monitor, res models.CheckResult, inc *models.Incident) {
+		alerts = append(alerts, sentAlert{res: res, inc: inc})
+	}
+
+	return m, &alerts
+}
+
+func TestHandleSOASerialAlertsOnSingleRollback(t *testing.T) {
+	m, alerts := newTestManager(t)
+	ctx := context.Background()
+
+	config, _ := json.Marshal(models.DNSConfig{RecordType: "SOA"})
+	mon := &models.Monitor{ID: 1, Type: models.TypeDNS, Config: config, LastCheckStatus: models.StatusUp}
+
+	check := func(serial string) models.CheckResult {
+		res := models.CheckResult{MonitorID: mon.ID, Status: models.StatusUp, ResultValue: serial}
+		m.handleSOASerial(ctx, mon, &res)
+		return res
+	}
+
+	check("2024010102")
+	if len(*alerts) != 0 {
+		t.Fatalf("baseline check sent %d alerts", len(*alerts))
+	}
+
+	res := check("2024010101")
+	if len(*alerts) != 1 {
+		t.Fatalf("rollback sent %d alerts, want 1", len(*alerts))
+	}
+
+	alert := (*alerts)[0].res
+	if alert.Status != models.StatusDown || !strings.Contains(alert.Message, "went backwards (2024010102 -> 2024010101)") {
+		t.Fatalf("alert = %s %q", alert.Status, alert.Message)
+	}
+
+	// The alert does not depend on the failure threshold confirming a down
+	// result, so the stored result stays up.
+	if res.Status != models.StatusUp {
+		t.Fatalf("result status = %s, want up", res.Status)
+	}
+
+	check("2024010101")
+	check("2024010103")
+	if len(*alerts) != 1 {
+		t.Fatalf("got %d alerts after the rollback, want 1", len(*alerts))
+	}
+}
+
+func TestSerialLess(t *testing.T) {
+	tests := []struct {
+		a, b uint32
+		want bool
+	}{
+		{a: 1, b: 2, want: true},
+		{a: 2, b: 1, want: false},
+		{a: 5, b: 5, want: false},
+		{a: 2024010101, b: 2024010102, want: true},
+		{a: 0xffffffff, b: 0, want: true},
+		{a: 0, b: 0xffffffff, want: false},
+		{a: 10, b: 0xfffffff0, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := serialLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("serialLess(%d, %d) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
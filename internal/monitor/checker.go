@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+// Checker runs the check for one monitor type and validates the config
+// users submit for it.
+type Checker interface {
+	Check(ctx context.Context, m models.Monitor) models.CheckResult
+	ValidateConfig(config json.RawMessage) error
+}
+
+// NewChecker builds a Checker from plain functions. validate may be nil when
+// the type accepts any config.
+func NewChecker(check func(ctx context.Context, m models.Monitor) models.CheckResult, validate func(config json.RawMessage) error) Checker {
+	return funcChecker{check: check, validate: validate}
+}
+
+type funcChecker struct {
+	check    func(ctx context.Context, m models.Monitor) models.CheckResult
+	validate func(config json.RawMessage) error
+}
+
+func (c funcChecker) Check(ctx context.Context, m models.Monitor) models.CheckResult {
+	return c.check(ctx, m)
+}
+
+func (c funcChecker) ValidateConfig(config json.RawMessage) error {
+	if c.validate == nil {
+		return nil
+	}
+	return c.validate(config)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[models.MonitorType]Checker)
+)
+
+// Register makes a monitor type available to the worker and the API.
+// Registering a type again replaces its checker.
+func Register(monitorType models.MonitorType, checker Checker) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[monitorType] = checker
+}
+
+func Lookup(monitorType models.MonitorType) (Checker, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	checker, ok := registry[monitorType]
+	return checker, ok
+}
+
+// RegisteredTypes lists the registered monitor types in alphabetical order.
+func RegisteredTypes() []models.MonitorType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]models.MonitorType, 0, len(registry))
+	for t := range registry {
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// closeOnCancel closes conn as soon as ctx is cancelled so blocked reads and
+// writes return right away. Calling the returned func stops the watch.
+func closeOnCancel(ctx context.Context, conn io.Closer) func() bool {
+	return context.AfterFunc(ctx, func() { _ = conn.Close() })
+}
+
+func init() {
+	Register(models.TypeHTTP, NewChecker(checkHTTP, validateHTTPConfig))
+	Register(models.TypeDNS, NewChecker(checkDNS, validateDNSConfig))
+	Register(models.TypePort, NewChecker(checkPort, nil))
+	Register(models.TypeTLS, NewChecker(checkTLS, validateTLSConfig))
+	Register(models.TypeICMP, NewChecker(checkICMP, validatePingConfig))
+	Register(models.TypeUDP, NewChecker(checkUDP, validateUDPConfig))
+	Register(models.TypeDomain, NewChecker(checkDomain, validateDomainConfig))
+	Register(models.TypePostgres, NewChecker(checkPostgres, validateDatabaseConfig))
+	Register(models.TypeRedis, NewChecker(checkRedis, validateRedisConfig))
+	Register(models.TypeMySQL, NewChecker(checkMySQL, validateDatabaseConfig))
+	Register(models.TypeSMTP, NewChecker(checkMail, validateMailConfig))
+	Register(models.TypeIMAP, NewChecker(checkMail, validateMailConfig))
+	Register(models.TypePOP3, NewChecker(checkMail, validateMailConfig))
+	Register(models.TypeGRPC, NewChecker(checkGRPC, validateGRPCConfig))
+	Register(models.TypeWebSocket, NewChecker(checkWebSocket, validateWebSocketConfig))
+	Register(models.TypeTransaction, NewChecker(checkTransaction, validateTransactionConfig))
+	Register(models.TypeContent, NewChecker(checkContent, validateContentConfig))
+	Register(models.TypePrometheus, NewChecker(checkPrometheus, validatePrometheusConfig))
+	Register(models.TypeSSH, NewChecker(checkSSH, validateSSHConfig))
+	Register(models.TypeNTP, NewChecker(checkNTP, validateNTPConfig))
+	Register(models.TypeDNSBL, NewChecker(checkDNSBL, validateDNSBLConfig))
+}
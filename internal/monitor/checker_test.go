@@ -0,0 +1,29 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestRegistryCoversEveryType(t *testing.T) {
+	RegisterHeartbeat(nil)
+
+	types := []models.MonitorType{
+		models.TypeHTTP, models.TypePort, models.TypeDNS, models.TypeTLS, models.TypeICMP, models.TypeUDP,
+		models.TypeHeartbeat, models.TypeDomain, models.TypePostgres, models.TypeRedis, models.TypeMySQL,
+		models.TypeSMTP, models.TypeIMAP, models.TypePOP3, models.TypeGRPC, models.TypeWebSocket,
+		models.TypeTransaction, models.TypeContent, models.TypePrometheus, models.TypeSSH, models.TypeNTP,
+		models.TypeDNSBL,
+	}
+
+	for _, monitorType := range types {
+		if _, ok := Lookup(monitorType); !ok {
+			t.Errorf("no checker registered for %s", monitorType)
+		}
+	}
+
+	if got := len(RegisteredTypes()); got != len(types) {
+		t.Errorf("%d types registered, want %d", got, len(types))
+	}
+}
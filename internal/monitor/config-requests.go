@@ -0,0 +1,74 @@
+package monitor
+
+// Config request types carry the struct tag rules for the config fields that
+// need more than a JSON type check. They live next to the validators so the
+// monitor package does not depend on the API's DTOs.
+
+type dnsConfigRequest struct {
+	RecordType   string   `json:"record_type" validate:"required_unless=Mode deliverability,omitempty,oneof=A AAAA MX NS TXT CNAME SOA SRV CAA PTR DS DNSKEY"`
+	Resolvers    []string `json:"resolvers" validate:"omitempty,dive,required"`
+	Policy       string   `json:"policy" validate:"omitempty,oneof=any all majority"`
+	SerialMaxAge string   `json:"serial_max_age" validate:"omitempty"`
+	Mode         string   `json:"mode" validate:"omitempty,oneof=propagation deliverability"`
+
+	DKIMSelectors []string `json:"dkim_selectors" validate:"omitempty,dive,required"`
+	SPFAll        string   `json:"spf_all" validate:"omitempty,oneof=-all ~all ?all"`
+	DMARCPolicy   string   `json:"dmarc_policy" validate:"omitempty,oneof=none quarantine reject"`
+}
+
+type pingConfigRequest struct {
+	Count        int     `json:"count" validate:"min=0,max=20"`
+	DegradedLoss float64 `json:"degraded_loss_percent" validate:"min=0,max=100"`
+}
+
+type databaseConfigRequest struct {
+	Username string `json:"username" validate:"required"`
+	SSLMode  string `json:"ssl_mode" validate:"omitempty,oneof=disable allow prefer require verify-ca verify-full"`
+}
+
+type redisConfigRequest struct {
+	DB           int    `json:"db" validate:"min=0"`
+	ExpectedRole string `json:"expected_role" validate:"omitempty,oneof=master slave primary replica"`
+}
+
+type mailConfigRequest struct {
+	AuthMechanisms []string `json:"auth_mechanisms" validate:"omitempty,dive,required"`
+}
+
+type transactionConfigRequest struct {
+	Steps []transactionStepRequest `json:"steps" validate:"required,min=1,max=20,dive"`
+}
+
+type transactionStepRequest struct {
+	Extract []extractionRequest `json:"extract" validate:"omitempty,dive"`
+}
+
+type extractionRequest struct {
+	Name       string `json:"name" validate:"required"`
+	Source     string `json:"source" validate:"required,oneof=json_path header regex"`
+	Expression string `json:"expression" validate:"required"`
+}
+
+type prometheusConfigRequest struct {
+	Metric    string `json:"metric" validate:"required"`
+	Aggregate string `json:"aggregate" validate:"omitempty,oneof=sum min max avg"`
+	Operator  string `json:"operator" validate:"omitempty,oneof=> >= < <= == !="`
+}
+
+type sshConfigRequest struct {
+	HostKeyAlgorithm string `json:"host_key_algorithm" validate:"omitempty,oneof=ssh-ed25519 ecdsa-sha2-nistp256 ecdsa-sha2-nistp384 ecdsa-sha2-nistp521 rsa-sha2-512 rsa-sha2-256 ssh-rsa"`
+}
+
+type ntpConfigRequest struct {
+	DegradedOffset float64 `json:"degraded_offset_ms" validate:"min=0"`
+	DownOffset     float64 `json:"down_offset_ms" validate:"min=0"`
+}
+
+type dnsblConfigRequest struct {
+	Zones    []string `json:"zones" validate:"omitempty,dive,hostname_rfc1123"`
+	Resolver string   `json:"resolver" validate:"omitempty"`
+}
+
+type domainConfigRequest struct {
+	RDAPURL string `json:"rdap_url" validate:"omitempty,url"`
+}
@@ -0,0 +1,549 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"golang.org/x/net/html"
+)
+
+const (
+	MaxContentSnapshot = 64 << 10
+	MaxDiffLines       = 20
+	MaxDiffLineLength  = 200
+)
+
+// Elements whose text runs on in the same line as their neighbours. Any other
+// element starts a new line so diffs stay readable.
+var inlineElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "code": true, "em": true, "i": true,
+	"label": true, "mark": true, "s": true, "small": true, "span": true,
+	"strong": true, "sub": true, "sup": true, "time": true, "u": true,
+}
+
+var hiddenElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "head": true,
+}
+
+func checkContent(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.ContentConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] Content configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	acceptedCodes, err := parseStatusRanges(config.AcceptedStatusCodes)
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid accepted status codes: %s", err.Error()), CheckedAt: time.Now()}
+	}
+
+	followRedirects := config.FollowRedirects == nil || *config.FollowRedirects
+
+	maxRedirects := config.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+
+	client := http.Client{
+		Timeout: m.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if !followRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return nil
+		},
+	}
+
+	req, err := buildHTTPRequest(m.Target, config.HTTPConfig)
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid HTTP request configuration: %s", err.Error()), CheckedAt: time.Now()}
+	}
+	req = req.WithContext(ctx)
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   err.Error(),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	latency := time.Since(start).Milliseconds()
+
+	fail := func(format string, args ...any) models.CheckResult {
+		return models.CheckResult{
+			MonitorID:  m.ID,
+			Status:     models.StatusDown,
+			Latency:    latency,
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf(format, args...),
+			CheckedAt:  time.Now(),
+		}
+	}
+
+	if err != nil {
+		return fail("Failed to read response body: %s", err.Error())
+	}
+
+	if !statusAccepted(acceptedCodes, resp.StatusCode) {
+		return fail("Unexpected status %s", resp.Status)
+	}
+
+	if failure := evaluateAssertions(config.Assertions, body); failure != "" {
+		return fail("%s", failure)
+	}
+
+	content, err := extractContent(body, config)
+	if err != nil {
+		return fail("%s", err.Error())
+	}
+
+	sum := sha256.Sum256([]byte(content))
+
+	details := models.ContentDetails{
+		Hash:     hex.EncodeToString(sum[:]),
+		Size:     len(content),
+		Snapshot: content,
+	}
+	if len(details.Snapshot) > MaxContentSnapshot {
+		details.Snapshot = details.Snapshot[:MaxContentSnapshot]
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      models.StatusUp,
+		Latency:     latency,
+		StatusCode:  resp.StatusCode,
+		ResultValue: details.Hash,
+		Message:     fmt.Sprintf("Content hashed (%d bytes)", details.Size),
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// extractContent returns the part of the body that is compared between
+// checks, one normalized line per block of text.
+func extractContent(body []byte, config models.ContentConfig) (string, error) {
+	var content string
+
+	switch {
+	case config.Selector != "":
+		selectors, err := parseSelector(config.Selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid selector: %w", err)
+		}
+
+		doc, err := html.Parse(bytes.NewReader(body))
+		if err != nil {
+			return "", fmt.Errorf("failed to parse HTML: %w", err)
+		}
+
+		var sb strings.Builder
+		matched := selectText(doc, selectors, &sb)
+		if matched == 0 {
+			return "", fmt.Errorf("selector '%s' matched no elements", config.Selector)
+		}
+		content = sb.String()
+
+	case config.Pattern != "":
+		re, err := regexp.Compile(config.Pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern: %w", err)
+		}
+
+		matches := re.FindAllSubmatch(body, -1)
+		if len(matches) == 0 {
+			return "", fmt.Errorf("body does not match /%s/", config.Pattern)
+		}
+
+		parts := make([]string, 0, len(matches))
+		for _, match := range matches {
+			// The first capture group wins; without groups the whole match is used.
+			if len(match) > 1 {
+				parts = append(parts, string(match[1]))
+			} else {
+				parts = append(parts, string(match[0]))
+			}
+		}
+		content = strings.Join(parts, "\n")
+
+	default:
+		content = string(body)
+	}
+
+	for _, pattern := range config.IgnorePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid ignore pattern '%s'", pattern)
+		}
+		content = re.ReplaceAllString(content, "")
+	}
+
+	return normalizeLines(content), nil
+}
+
+// normalizeLines collapses runs of whitespace and drops empty lines so
+// reformatting alone is not reported as a change.
+func normalizeLines(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// selectText writes the text of every element matching one of the selectors
+// and returns how many matched. Matches nested in a match are not repeated.
+func selectText(n *html.Node, selectors [][]selectorStep, sb *strings.Builder) int {
+	if n.Type == html.ElementNode {
+		for _, selector := range selectors {
+			if matchSelector(n, selector, len(selector)-1) {
+				writeText(n, sb)
+				sb.WriteString("\n")
+				return 1
+			}
+		}
+	}
+
+	matched := 0
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		matched += selectText(c, selectors, sb)
+	}
+	return matched
+}
+
+func writeText(n *html.Node, sb *strings.Builder) {
+	switch n.Type {
+	case html.TextNode:
+		sb.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		if hiddenElements[n.Data] {
+			return
+		}
+	}
+
+	block := n.Type == html.ElementNode && !inlineElements[n.Data]
+	if block {
+		sb.WriteString("\n")
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeText(c, sb)
+	}
+
+	if block {
+		sb.WriteString("\n")
+	}
+}
+
+// selectorStep is one compound selector such as div.price[data-id="1"].
+// child is set when it is joined to the previous step with '>'.
+type selectorStep struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   [][2]string
+	child   bool
+}
+
+// parseSelector supports a CSS subset: type, #id, .class, [attr] and
+// [attr=value] selectors, descendant and '>' combinators, and ',' groups.
+func parseSelector(s string) ([][]selectorStep, error) {
+	var selectors [][]selectorStep
+
+	for _, group := range strings.Split(s, ",") {
+		fields := strings.Fields(strings.ReplaceAll(group, ">", " > "))
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty selector in '%s'", s)
+		}
+
+		var steps []selectorStep
+		child := false
+
+		for _, field := range fields {
+			if field == ">" {
+				if len(steps) == 0 || child {
+					return nil, fmt.Errorf("misplaced '>' in '%s'", strings.TrimSpace(group))
+				}
+				child = true
+				continue
+			}
+
+			step, err := parseCompound(field)
+			if err != nil {
+				return nil, err
+			}
+			step.child = child
+			child = false
+
+			steps = append(steps, step)
+		}
+
+		if child {
+			return nil, fmt.Errorf("misplaced '>' in '%s'", strings.TrimSpace(group))
+		}
+
+		selectors = append(selectors, steps)
+	}
+
+	return selectors, nil
+}
+
+func parseCompound(s string) (selectorStep, error) {
+	var step selectorStep
+
+	if strings.ContainsAny(s, ":+~()") {
+		return step, fmt.Errorf("pseudo-classes and sibling combinators are not supported ('%s')", s)
+	}
+
+	ident := func(i int) (string, int) {
+		end := i
+		for end < len(s) && !strings.ContainsRune("#.[", rune(s[end])) {
+			end++
+		}
+		return s[i:end], end
+	}
+
+	var i int
+	step.tag, i = ident(0)
+	step.tag = strings.ToLower(step.tag)
+	if step.tag == "*" {
+		step.tag = ""
+	}
+
+	for i < len(s) {
+		switch s[i] {
+		case '#', '.':
+			kind := s[i]
+			name, end := ident(i + 1)
+			if name == "" {
+				return step, fmt.Errorf("missing name after '%c' in '%s'", kind, s)
+			}
+
+			if kind == '#' {
+				step.id = name
+			} else {
+				step.classes = append(step.classes, name)
+			}
+			i = end
+
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return step, fmt.Errorf("unclosed '[' in '%s'", s)
+			}
+
+			name, value, _ := strings.Cut(s[i+1:i+end], "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				return step, fmt.Errorf("missing attribute name in '%s'", s)
+			}
+
+			step.attrs = append(step.attrs, [2]string{name, strings.Trim(value, `"'`)})
+			i += end + 1
+
+		default:
+			return step, fmt.Errorf("unexpected '%c' in '%s'", s[i], s)
+		}
+	}
+
+	return step, nil
+}
+
+func matchSelector(n *html.Node, steps []selectorStep, i int) bool {
+	if !matchStep(n, steps[i]) {
+		return false
+	}
+
+	if i == 0 {
+		return true
+	}
+
+	if steps[i].child {
+		return n.Parent != nil && matchSelector(n.Parent, steps, i-1)
+	}
+
+	for p := n.Parent; p != nil; p = p.Parent {
+		if matchSelector(p, steps, i-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchStep(n *html.Node, step selectorStep) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+
+	if step.tag != "" && n.Data != step.tag {
+		return false
+	}
+
+	if step.id != "" && attr(n, "id") != step.id {
+		return false
+	}
+
+	classes := strings.Fields(attr(n, "class"))
+	for _, class := range step.classes {
+		if !containsString(classes, class) {
+			return false
+		}
+	}
+
+	for _, a := range step.attrs {
+		value, ok := lookupAttr(n, a[0])
+		if !ok || (a[1] != "" && value != a[1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func attr(n *html.Node, name string) string {
+	value, _ := lookupAttr(n, name)
+	return value
+}
+
+func lookupAttr(n *html.Node, name string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// diffLines compares two snapshots line by line and returns the changed
+// lines prefixed with '-' and '+', capped at MaxDiffLines.
+func diffLines(before, after string) (string, int, int) {
+	a, b := splitLines(before), splitLines(after)
+
+	// Trim the common prefix and suffix; pages usually change in one spot.
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+
+	var ops []string
+
+	if len(a)*len(b) > 1<<20 {
+		// Too large for the LCS table: report the block as replaced.
+		for _, line := range a {
+			ops = append(ops, "- "+line)
+		}
+		for _, line := range b {
+			ops = append(ops, "+ "+line)
+		}
+	} else {
+		ops = lcsDiff(a, b)
+	}
+
+	var added, removed int
+	for _, op := range ops {
+		if op[0] == '+' {
+			added++
+		} else {
+			removed++
+		}
+	}
+
+	shown := ops
+	if len(shown) > MaxDiffLines {
+		shown = shown[:MaxDiffLines]
+	}
+
+	lines := make([]string, 0, len(shown)+1)
+	for _, op := range shown {
+		lines = append(lines, truncate(op, MaxDiffLineLength))
+	}
+	if len(ops) > len(shown) {
+		lines = append(lines, fmt.Sprintf("... %d more changed lines", len(ops)-len(shown)))
+	}
+
+	return strings.Join(lines, "\n"), added, removed
+}
+
+func lcsDiff(a, b []string) []string {
+	width := len(b) + 1
+	table := make([]int32, (len(a)+1)*width)
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i*width+j] = table[(i+1)*width+j+1] + 1
+			} else {
+				table[i*width+j] = max(table[(i+1)*width+j], table[i*width+j+1])
+			}
+		}
+	}
+
+	var ops []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case table[(i+1)*width+j] >= table[i*width+j+1]:
+			ops = append(ops, "- "+a[i])
+			i++
+		default:
+			ops = append(ops, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, "+ "+b[j])
+	}
+
+	return ops
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
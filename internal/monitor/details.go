@@ -0,0 +1,21 @@
+package monitor
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// marshalDetails encodes the type specific details of a check result,
+// returning nil when there is nothing worth storing.
+func marshalDetails(details any) json.RawMessage {
+	if details == nil || reflect.ValueOf(details).IsZero() {
+		return nil
+	}
+
+	data, err := json.Marshal(details)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
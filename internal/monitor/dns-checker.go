@@ -2,65 +2,142 @@ package monitor
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ghduuep/pingly/internal/models"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
-func checkDNS(m models.Monitor) models.CheckResult {
+const (
+	DNSModePropagation    = "propagation"
+	DNSModeDeliverability = "deliverability"
+)
+
+const (
+	typeDS     dnsmessage.Type = 43
+	typeDNSKEY dnsmessage.Type = 48
+	typeCAA    dnsmessage.Type = 257
+)
+
+func checkDNS(ctx context.Context, m models.Monitor) models.CheckResult {
 	var config models.DNSConfig
 	if err := json.Unmarshal(m.Config, &config); err != nil {
 		return models.CheckResult{Status: models.StatusDown, Message: "[ERROR] DNS configuration error.", CheckedAt: time.Now()}
 	}
 
-	r := &net.Resolver{
-		PreferGo: true,
-		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-			d := net.Dialer{
-				Timeout: m.Timeout,
-			}
-			return d.DialContext(ctx, network, "8.8.8.8:53")
-		},
+	specs := config.Resolvers
+	if len(specs) == 0 {
+		specs = []string{DefaultResolver}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), m.Timeout)
+	resolvers := make([]*dnsResolver, 0, len(specs))
+	for _, spec := range specs {
+		r, err := newResolver(spec, m.Timeout)
+		if err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: err.Error(), CheckedAt: time.Now()}
+		}
+		resolvers = append(resolvers, r)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
 	defer cancel()
 
-	var resultString string
-	var err error
+	start := time.Now()
 
-	switch config.RecordType {
-	case "A":
-		resultString, err = lookupIP(ctx, r, m.Target, "ip4")
-	case "AAAA":
-		resultString, err = lookupIP(ctx, r, m.Target, "ip6")
-	case "MX":
-		resultString, err = lookupMX(ctx, r, m.Target)
-	case "NS":
-		resultString, err = lookupNS(ctx, r, m.Target)
-	case "TXT":
-		resultString, err = lookupTXT(ctx, r, m.Target)
-	case "CNAME":
-		resultString, err = lookupCNAME(ctx, r, m.Target)
-	default:
-		return models.CheckResult{Status: models.StatusDown, Message: "Invalid DNS record type."}
+	mode := strings.ToLower(config.Mode)
+
+	if mode == DNSModeDeliverability {
+		return checkDeliverability(ctx, m, config, resolvers[0])
 	}
 
-	if err != nil {
+	var zone string
+
+	if mode == DNSModePropagation {
+		authoritative, z, err := authoritativeResolvers(ctx, resolvers[0], m.Target, m.Timeout)
+		if err != nil {
+			return models.CheckResult{
+				MonitorID: m.ID,
+				Status:    models.StatusDown,
+				Latency:   time.Since(start).Milliseconds(),
+				Message:   fmt.Sprintf("Could not discover authoritative nameservers: %s", err.Error()),
+				CheckedAt: time.Now(),
+			}
+		}
+		resolvers, zone = authoritative, z
+	}
+
+	answers := queryResolvers(ctx, resolvers, config.RecordType, m.Target)
+	latency := time.Since(start).Milliseconds()
+
+	details := models.DNSDetails{Mode: mode, Zone: zone, Answers: answers}
+
+	var firstErr string
+	for _, a := range answers {
+		if a.Error != "" && firstErr == "" {
+			firstErr = a.Error
+		}
+	}
+
+	currentValue, succeeded := consensusValue(answers)
+
+	if succeeded == 0 {
 		return models.CheckResult{
 			MonitorID: m.ID,
 			Status:    models.StatusDown,
-			Message:   fmt.Sprintf("Could not resolve the specified %s record for target %s", config.RecordType, err.Error()),
+			Latency:   latency,
+			Message:   fmt.Sprintf("Could not resolve the specified %s record for target %s", config.RecordType, firstErr),
+			Details:   marshalDetails(details),
 			CheckedAt: time.Now(),
 		}
 	}
 
-	currentValue := strings.TrimSpace(resultString)
+	// Without a learned value yet (or for SOA, which has none), the
+	// authoritative servers must at least agree with each other.
+	if mode == DNSModePropagation && (config.RecordType == "SOA" || strings.TrimSpace(config.ExpectedValue) == "") {
+		disagreeing := 0
+		for i := range answers {
+			answers[i].Matches = answers[i].Error == "" && answers[i].Value == currentValue
+			if !answers[i].Matches {
+				disagreeing++
+			}
+		}
+
+		if disagreeing > 0 {
+			return models.CheckResult{
+				MonitorID:   m.ID,
+				Status:      models.StatusDown,
+				Latency:     latency,
+				Message:     fmt.Sprintf("%d/%d authoritative nameservers of %s disagree", disagreeing, len(answers), zone),
+				ResultValue: disagreementSummary(answers),
+				Details:     marshalDetails(details),
+				CheckedAt:   time.Now(),
+			}
+		}
+	}
+
+	// SOA serials are expected to change, so they are tracked over time by
+	// the manager instead of being compared against a learned value.
+	if config.RecordType == "SOA" {
+		return models.CheckResult{
+			MonitorID:   m.ID,
+			Status:      models.StatusUp,
+			Latency:     latency,
+			ResultValue: currentValue,
+			Message:     fmt.Sprintf("Zone serial %s", currentValue),
+			Details:     marshalDetails(details),
+			CheckedAt:   time.Now(),
+		}
+	}
+
 	expectedParts := strings.Split(config.ExpectedValue, ",")
 
 	for i := range expectedParts {
@@ -75,18 +152,60 @@ func checkDNS(m models.Monitor) models.CheckResult {
 		return models.CheckResult{
 			MonitorID:   m.ID,
 			Status:      models.StatusUp,
+			Latency:     latency,
 			ResultValue: currentValue,
 			Message:     "DNS values detected",
+			Details:     marshalDetails(details),
 			CheckedAt:   time.Now(),
 		}
 	}
 
-	if currentValue != expectedValue {
+	matches := 0
+	var mismatch *models.ResolverAnswer
+	for i := range answers {
+		answers[i].Matches = answers[i].Error == "" && answers[i].Value == expectedValue
+		if answers[i].Matches {
+			matches++
+		} else if mismatch == nil {
+			mismatch = &answers[i]
+		}
+	}
+
+	policy := strings.ToLower(config.Policy)
+	if policy == "" {
+		policy = "all"
+	}
+
+	details.Policy = policy
+
+	if !policySatisfied(policy, matches, len(answers)) {
+		found := mismatch.Value
+		if mismatch.Error != "" {
+			found = mismatch.Error
+		}
+
+		message := fmt.Sprintf("DNS record mismatch. Expected '%s', Found '%s'", expectedValue, found)
+		if len(answers) > 1 {
+			message = fmt.Sprintf("DNS record mismatch on %d/%d resolvers (policy: %s). Expected '%s', Found '%s' at %s", len(answers)-matches, len(answers), policy, expectedValue, found, mismatch.Resolver)
+		}
+
+		resultValue := currentValue
+		if mismatch.Error == "" {
+			resultValue = mismatch.Value
+		}
+
+		if mode == DNSModePropagation {
+			message = fmt.Sprintf("%d/%d authoritative nameservers of %s do not serve the expected value '%s'", len(answers)-matches, len(answers), zone, expectedValue)
+			resultValue = disagreementSummary(answers)
+		}
+
 		return models.CheckResult{
 			MonitorID:   m.ID,
 			Status:      models.StatusDown,
-			Message:     fmt.Sprintf("DNS record mismatch. Expected '%s', Found '%s'", expectedValue, currentValue),
-			ResultValue: currentValue,
+			Latency:     latency,
+			Message:     message,
+			ResultValue: resultValue,
+			Details:     marshalDetails(details),
 			CheckedAt:   time.Now(),
 		}
 	}
@@ -94,11 +213,183 @@ func checkDNS(m models.Monitor) models.CheckResult {
 	return models.CheckResult{
 		MonitorID:   m.ID,
 		Status:      models.StatusUp,
-		ResultValue: resultString,
+		Latency:     latency,
+		ResultValue: expectedValue,
+		Details:     marshalDetails(details),
 		CheckedAt:   time.Now(),
 	}
 }
 
+// queryResolvers looks the record up on every resolver concurrently and
+// returns the answers in the order the resolvers were configured.
+func queryResolvers(ctx context.Context, resolvers []*dnsResolver, recordType, target string) []models.ResolverAnswer {
+	answers := make([]models.ResolverAnswer, len(resolvers))
+
+	var wg sync.WaitGroup
+	for i, r := range resolvers {
+		wg.Add(1)
+		go func(i int, r *dnsResolver) {
+			defer wg.Done()
+
+			start := time.Now()
+			value, err := lookupRecord(ctx, r, recordType, target)
+
+			answers[i] = models.ResolverAnswer{
+				Resolver: r.Address,
+				Value:    strings.TrimSpace(value),
+				Latency:  time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				answers[i].Error = err.Error()
+			}
+		}(i, r)
+	}
+	wg.Wait()
+
+	return answers
+}
+
+func lookupRecord(ctx context.Context, r *dnsResolver, recordType, target string) (string, error) {
+	switch recordType {
+	case "A":
+		return lookupIP(ctx, r.Resolver, target, "ip4")
+	case "AAAA":
+		return lookupIP(ctx, r.Resolver, target, "ip6")
+	case "MX":
+		return lookupMX(ctx, r.Resolver, target)
+	case "NS":
+		return lookupNS(ctx, r.Resolver, target)
+	case "TXT":
+		return lookupTXT(ctx, r.Resolver, target)
+	case "CNAME":
+		return lookupCNAME(ctx, r.Resolver, target)
+	case "SRV":
+		return lookupSRV(ctx, r.Resolver, target)
+	case "PTR":
+		return lookupPTR(ctx, r.Resolver, target)
+	case "SOA":
+		return lookupSOA(ctx, r, target)
+	case "CAA":
+		return lookupCAA(ctx, r, target)
+	case "DS":
+		return lookupDS(ctx, r, target)
+	case "DNSKEY":
+		return lookupDNSKEY(ctx, r, target)
+	default:
+		return "", fmt.Errorf("invalid DNS record type")
+	}
+}
+
+// consensusValue returns the answer given by most resolvers and how many
+// resolvers answered without error.
+func consensusValue(answers []models.ResolverAnswer) (string, int) {
+	counts := make(map[string]int)
+	var best string
+	succeeded := 0
+
+	for _, a := range answers {
+		if a.Error != "" {
+			continue
+		}
+		succeeded++
+		counts[a.Value]++
+		if counts[a.Value] > counts[best] {
+			best = a.Value
+		}
+	}
+
+	return best, succeeded
+}
+
+// authoritativeResolvers discovers the zone that contains target and returns
+// a resolver pinned to each of its authoritative nameservers.
+func authoritativeResolvers(ctx context.Context, base *dnsResolver, target string, timeout time.Duration) ([]*dnsResolver, string, error) {
+	name := strings.TrimSuffix(target, ".")
+
+	var nss []*net.NS
+	for name != "" {
+		found, err := base.Resolver.LookupNS(ctx, name)
+		if err == nil && len(found) > 0 {
+			nss = found
+			break
+		}
+
+		if ctx.Err() != nil {
+			return nil, "", ctx.Err()
+		}
+
+		_, parent, ok := strings.Cut(name, ".")
+		if !ok {
+			break
+		}
+		name = parent
+	}
+
+	if len(nss) == 0 {
+		return nil, "", fmt.Errorf("no NS records found for %s or its parents", target)
+	}
+
+	sort.Slice(nss, func(i, j int) bool { return nss[i].Host < nss[j].Host })
+
+	resolvers := make([]*dnsResolver, 0, len(nss))
+	for _, ns := range nss {
+		host := strings.TrimSuffix(ns.Host, ".")
+
+		ips, err := base.Resolver.LookupIP(ctx, "ip", host)
+		if err != nil || len(ips) == 0 {
+			return nil, "", fmt.Errorf("could not resolve nameserver %s", host)
+		}
+
+		ip := ips[0]
+		for _, candidate := range ips {
+			if candidate.To4() != nil {
+				ip = candidate
+				break
+			}
+		}
+
+		r, err := newResolver(net.JoinHostPort(ip.String(), "53"), timeout)
+		if err != nil {
+			return nil, "", err
+		}
+		r.Address = fmt.Sprintf("%s (%s)", host, ip)
+
+		resolvers = append(resolvers, r)
+	}
+
+	return resolvers, name, nil
+}
+
+// disagreementSummary lists the servers whose answer does not match, in the
+// "server: value" form shown in alerts.
+func disagreementSummary(answers []models.ResolverAnswer) string {
+	var parts []string
+	for _, a := range answers {
+		if a.Matches {
+			continue
+		}
+
+		value := a.Value
+		if a.Error != "" {
+			value = "error: " + a.Error
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", a.Resolver, value))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+func policySatisfied(policy string, matches, total int) bool {
+	switch policy {
+	case "any":
+		return matches > 0
+	case "majority":
+		return matches*2 > total
+	default:
+		return matches == total
+	}
+}
+
 func lookupIP(ctx context.Context, r *net.Resolver, host string, network string) (string, error) {
 	ips, err := r.LookupIP(ctx, network, host)
 	if err != nil {
@@ -166,3 +457,148 @@ func lookupCNAME(ctx context.Context, r *net.Resolver, host string) (string, err
 
 	return strings.TrimSpace(cname), nil
 }
+
+func lookupSRV(ctx context.Context, r *net.Resolver, host string) (string, error) {
+	_, srvs, err := r.LookupSRV(ctx, "", "", host)
+	if err != nil {
+		return "", err
+	}
+
+	var results []string
+	for _, srv := range srvs {
+		results = append(results, fmt.Sprintf("%s:%d (%d %d)", srv.Target, srv.Port, srv.Priority, srv.Weight))
+	}
+
+	sort.Strings(results)
+
+	return strings.Join(results, ", "), nil
+}
+
+func lookupPTR(ctx context.Context, r *net.Resolver, addr string) (string, error) {
+	names, err := r.LookupAddr(ctx, addr)
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", "), nil
+}
+
+// lookupSOA returns the zone serial, which is what SOA monitors track.
+func lookupSOA(ctx context.Context, r *dnsResolver, host string) (string, error) {
+	answers, err := r.exchange(ctx, host, dnsmessage.TypeSOA)
+	if err != nil {
+		return "", err
+	}
+
+	soa, ok := answers[0].Body.(*dnsmessage.SOAResource)
+	if !ok {
+		return "", fmt.Errorf("malformed SOA record")
+	}
+
+	return strconv.FormatUint(uint64(soa.Serial), 10), nil
+}
+
+func lookupCAA(ctx context.Context, r *dnsResolver, host string) (string, error) {
+	answers, err := r.exchange(ctx, host, typeCAA)
+	if err != nil {
+		return "", err
+	}
+
+	var results []string
+	for _, rr := range answers {
+		data := rawData(rr)
+		if len(data) < 2 || len(data) < 2+int(data[1]) {
+			return "", fmt.Errorf("malformed CAA record")
+		}
+
+		flags, tagLen := data[0], int(data[1])
+		tag := string(data[2 : 2+tagLen])
+		value := string(data[2+tagLen:])
+
+		results = append(results, fmt.Sprintf("%d %s \"%s\"", flags, tag, value))
+	}
+
+	sort.Strings(results)
+
+	return strings.Join(results, ", "), nil
+}
+
+func lookupDS(ctx context.Context, r *dnsResolver, host string) (string, error) {
+	answers, err := r.exchange(ctx, host, typeDS)
+	if err != nil {
+		return "", err
+	}
+
+	var results []string
+	for _, rr := range answers {
+		data := rawData(rr)
+		if len(data) < 4 {
+			return "", fmt.Errorf("malformed DS record")
+		}
+
+		keyTag := binary.BigEndian.Uint16(data[0:2])
+		results = append(results, fmt.Sprintf("%d %d %d %s", keyTag, data[2], data[3], strings.ToUpper(hex.EncodeToString(data[4:]))))
+	}
+
+	sort.Strings(results)
+
+	return strings.Join(results, ", "), nil
+}
+
+func lookupDNSKEY(ctx context.Context, r *dnsResolver, host string) (string, error) {
+	answers, err := r.exchange(ctx, host, typeDNSKEY)
+	if err != nil {
+		return "", err
+	}
+
+	var results []string
+	for _, rr := range answers {
+		data := rawData(rr)
+		if len(data) < 4 {
+			return "", fmt.Errorf("malformed DNSKEY record")
+		}
+
+		flags := binary.BigEndian.Uint16(data[0:2])
+		results = append(results, fmt.Sprintf("%d %d %d (tag %d)", flags, data[2], data[3], keyTag(data)))
+	}
+
+	sort.Strings(results)
+
+	return strings.Join(results, ", "), nil
+}
+
+func rawData(rr dnsmessage.Resource) []byte {
+	if unknown, ok := rr.Body.(*dnsmessage.UnknownResource); ok {
+		return unknown.Data
+	}
+	return nil
+}
+
+// keyTag computes the RFC 4034 Appendix B key tag of a DNSKEY rdata.
+func keyTag(rdata []byte) uint16 {
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 1 {
+			ac += uint32(b)
+		} else {
+			ac += uint32(b) << 8
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return uint16(ac & 0xFFFF)
+}
+
+func typeName(t dnsmessage.Type) string {
+	switch t {
+	case typeDS:
+		return "DS"
+	case typeDNSKEY:
+		return "DNSKEY"
+	case typeCAA:
+		return "CAA"
+	default:
+		return strings.TrimPrefix(t.String(), "Type")
+	}
+}
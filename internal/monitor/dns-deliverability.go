@@ -0,0 +1,654 @@
+package monitor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	// RFC 7208 section 4.6.4 caps the DNS-querying terms of an SPF check.
+	MaxSPFLookups = 10
+	// RFC 8301 forbids verifiers from accepting shorter RSA keys.
+	MinDKIMKeyBits = 1024
+)
+
+var errSPFLookupLimit = fmt.Errorf("too many DNS lookups (limit %d)", MaxSPFLookups)
+
+var (
+	spfQualifierStrength = map[string]int{"+": 0, "?": 1, "~": 2, "-": 3}
+	dmarcPolicyStrength  = map[string]int{"none": 0, "quarantine": 1, "reject": 2}
+)
+
+// checkDeliverability validates the SPF, DMARC and DKIM records of the target
+// domain. Broken records take the monitor down; records that are valid but
+// weaker than the configured policy degrade it.
+func checkDeliverability(ctx context.Context, m models.Monitor, config models.DNSConfig, r *dnsResolver) models.CheckResult {
+	domain := strings.TrimSuffix(strings.TrimSpace(m.Target), ".")
+
+	start := time.Now()
+
+	details := models.DeliverabilityDetails{Mode: DNSModeDeliverability}
+	var problems, warnings []string
+
+	details.SPF = checkSPF(ctx, r.Resolver, domain)
+	if details.SPF.Error != "" {
+		problems = append(problems, "SPF: "+details.SPF.Error)
+	} else if config.SPFAll != "" {
+		qualifier, label := "?", "no 'all' mechanism"
+		if details.SPF.All != "" {
+			qualifier, label = details.SPF.All[:1], details.SPF.All
+		}
+
+		if spfQualifierStrength[qualifier] < spfQualifierStrength[config.SPFAll[:1]] {
+			warnings = append(warnings, fmt.Sprintf("SPF ends with %s, policy requires %s", label, config.SPFAll))
+		}
+	}
+
+	details.DMARC = checkDMARC(ctx, r.Resolver, domain)
+	if details.DMARC.Error != "" {
+		problems = append(problems, "DMARC: "+details.DMARC.Error)
+	} else if config.DMARCPolicy != "" {
+		// An inherited record applies its subdomain policy when it has one.
+		tag, policy := "p", details.DMARC.Policy
+		if details.DMARC.OrganizationalDomain != "" && details.DMARC.SubdomainPolicy != "" {
+			tag, policy = "sp", details.DMARC.SubdomainPolicy
+		}
+
+		if dmarcPolicyStrength[policy] < dmarcPolicyStrength[config.DMARCPolicy] {
+			warnings = append(warnings, fmt.Sprintf("DMARC policy is %s=%s, policy requires p=%s", tag, policy, config.DMARCPolicy))
+		} else if details.DMARC.Percent < 100 && policy != "none" {
+			warnings = append(warnings, fmt.Sprintf("DMARC %s=%s applies to only %d%% of mail", tag, policy, details.DMARC.Percent))
+		}
+	}
+
+	validKeys := 0
+	for _, selector := range config.DKIMSelectors {
+		key := checkDKIM(ctx, r.Resolver, domain, strings.TrimSpace(selector))
+		if key.Error != "" {
+			problems = append(problems, fmt.Sprintf("DKIM %s: %s", key.Selector, key.Error))
+		} else {
+			validKeys++
+		}
+		details.DKIM = append(details.DKIM, key)
+	}
+
+	latency := time.Since(start).Milliseconds()
+
+	summary := []string{"SPF invalid", "DMARC invalid"}
+	if details.SPF.Error == "" {
+		summary[0] = "SPF " + details.SPF.All
+		if details.SPF.All == "" {
+			summary[0] = "SPF ?all"
+		}
+	}
+	if details.DMARC.Error == "" {
+		summary[1] = "DMARC p=" + details.DMARC.Policy
+	}
+	if len(config.DKIMSelectors) > 0 {
+		summary = append(summary, fmt.Sprintf("DKIM %d/%d", validKeys, len(config.DKIMSelectors)))
+	}
+
+	status := models.StatusUp
+	message := "SPF and DMARC records are valid"
+	if len(config.DKIMSelectors) > 0 {
+		message = "SPF, DMARC and DKIM records are valid"
+	}
+
+	if len(problems) > 0 {
+		status = models.StatusDown
+		message = strings.Join(problems, "; ")
+	} else if len(warnings) > 0 {
+		status = models.StatusDegraded
+		message = strings.Join(warnings, "; ")
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     latency,
+		ResultValue: strings.Join(summary, ", "),
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+func checkSPF(ctx context.Context, r *net.Resolver, domain string) models.SPFDetails {
+	var details models.SPFDetails
+
+	e := &spfEvaluator{ctx: ctx, resolver: r}
+
+	record, err := e.fetch(domain)
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+	details.Record = record
+
+	qualifier, err := e.evaluate(record)
+	details.Lookups = e.lookups
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+
+	if qualifier != "" {
+		details.All = qualifier + "all"
+	}
+
+	if qualifier == "+" {
+		details.Error = "record allows any sender (+all)"
+	}
+
+	return details
+}
+
+// spfEvaluator walks an SPF record and the records it includes, counting
+// the terms that cost a DNS lookup at the receiving server.
+type spfEvaluator struct {
+	ctx      context.Context
+	resolver *net.Resolver
+	lookups  int
+}
+
+// fetch returns the single SPF record published at domain.
+func (e *spfEvaluator) fetch(domain string) (string, error) {
+	txts, err := lookupTXTRecords(e.ctx, e.resolver, domain)
+	if err != nil {
+		return "", err
+	}
+
+	var records []string
+	for _, txt := range txts {
+		txt = strings.TrimSpace(txt)
+		if strings.EqualFold(txt, "v=spf1") || strings.HasPrefix(strings.ToLower(txt), "v=spf1 ") {
+			records = append(records, txt)
+		}
+	}
+
+	switch len(records) {
+	case 0:
+		return "", errors.New("no SPF record")
+	case 1:
+		return records[0], nil
+	default:
+		return "", fmt.Errorf("%d SPF records published, only one is allowed", len(records))
+	}
+}
+
+func (e *spfEvaluator) count() error {
+	e.lookups++
+	if e.lookups > MaxSPFLookups {
+		return errSPFLookupLimit
+	}
+	return nil
+}
+
+// evaluate checks the syntax of record, follows its includes and redirect,
+// and returns the qualifier of the "all" mechanism that ends it (empty when
+// there is none).
+func (e *spfEvaluator) evaluate(record string) (string, error) {
+	var all, redirect string
+	modifiers := make(map[string]bool)
+
+	for _, term := range strings.Fields(record)[1:] {
+		if name, value, ok := spfModifier(term); ok {
+			name = strings.ToLower(name)
+			if name != "redirect" && name != "exp" {
+				continue
+			}
+
+			if modifiers[name] {
+				return "", fmt.Errorf("duplicate %s modifier", name)
+			}
+			if value == "" {
+				return "", fmt.Errorf("empty %s modifier", name)
+			}
+			modifiers[name] = true
+
+			if name == "redirect" {
+				redirect = value
+			}
+			continue
+		}
+
+		qualifier := "+"
+		if strings.ContainsRune("+-~?", rune(term[0])) {
+			qualifier, term = term[:1], term[1:]
+		}
+
+		mechanism, arg := term, ""
+		if i := strings.IndexAny(term, ":/"); i >= 0 {
+			mechanism, arg = term[:i], term[i:]
+		}
+
+		switch strings.ToLower(mechanism) {
+		case "all":
+			if arg != "" {
+				return "", fmt.Errorf("invalid term '%s'", term)
+			}
+			if all == "" {
+				all = qualifier
+			}
+
+		case "include", "exists":
+			target, ok := strings.CutPrefix(arg, ":")
+			if !ok || target == "" {
+				return "", fmt.Errorf("'%s' requires a domain", mechanism)
+			}
+			if err := e.count(); err != nil {
+				return "", err
+			}
+			if strings.EqualFold(mechanism, "include") {
+				if err := e.include(target); err != nil {
+					return "", err
+				}
+			}
+
+		case "a", "mx":
+			if !validSPFDomainCIDR(arg) {
+				return "", fmt.Errorf("invalid term '%s'", term)
+			}
+			if err := e.count(); err != nil {
+				return "", err
+			}
+
+		case "ptr":
+			if arg != "" && (!strings.HasPrefix(arg, ":") || len(arg) == 1) {
+				return "", fmt.Errorf("invalid term '%s'", term)
+			}
+			if err := e.count(); err != nil {
+				return "", err
+			}
+
+		case "ip4", "ip6":
+			if !validSPFNetwork(strings.ToLower(mechanism), arg) {
+				return "", fmt.Errorf("invalid address in '%s'", term)
+			}
+
+		default:
+			return "", fmt.Errorf("unknown mechanism '%s'", term)
+		}
+	}
+
+	// A redirect only applies when the record has no "all" mechanism.
+	if redirect == "" || all != "" {
+		return all, nil
+	}
+
+	if err := e.count(); err != nil {
+		return "", err
+	}
+
+	// Macros expand per message, so the target cannot be followed here.
+	if strings.Contains(redirect, "%") {
+		return "", nil
+	}
+
+	target, err := e.fetch(redirect)
+	if err != nil {
+		return "", fmt.Errorf("redirect=%s: %w", redirect, err)
+	}
+
+	qualifier, err := e.evaluate(target)
+	if err != nil && !errors.Is(err, errSPFLookupLimit) {
+		return "", fmt.Errorf("redirect=%s: %w", redirect, err)
+	}
+	return qualifier, err
+}
+
+func (e *spfEvaluator) include(domain string) error {
+	if strings.Contains(domain, "%") {
+		return nil
+	}
+
+	record, err := e.fetch(domain)
+	if err == nil {
+		_, err = e.evaluate(record)
+	}
+
+	if err != nil && !errors.Is(err, errSPFLookupLimit) {
+		return fmt.Errorf("include:%s: %w", domain, err)
+	}
+	return err
+}
+
+// spfModifier splits a "name=value" term. Mechanisms may carry '=' only
+// after their ':' or '/' separator.
+func spfModifier(term string) (string, string, bool) {
+	i := strings.IndexByte(term, '=')
+	if i <= 0 || strings.ContainsAny(term[:i], ":/") {
+		return "", "", false
+	}
+
+	name := term[:i]
+	for j, c := range name {
+		alpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !alpha && (j == 0 || !strings.ContainsRune("0123456789-_.", c)) {
+			return "", "", false
+		}
+	}
+
+	return name, term[i+1:], true
+}
+
+// validSPFDomainCIDR checks the optional ":domain/cidr4//cidr6" suffix of
+// the a and mx mechanisms.
+func validSPFDomainCIDR(arg string) bool {
+	domain, cidr := arg, ""
+	if i := strings.IndexByte(arg, '/'); i >= 0 {
+		domain, cidr = arg[:i], arg[i:]
+	}
+
+	if domain != "" && (!strings.HasPrefix(domain, ":") || len(domain) == 1) {
+		return false
+	}
+
+	if cidr == "" {
+		return true
+	}
+
+	if v6, ok := strings.CutPrefix(cidr, "//"); ok {
+		return validPrefixLength(v6, 128)
+	}
+
+	v4, v6, dual := strings.Cut(cidr[1:], "//")
+	return validPrefixLength(v4, 32) && (!dual || validPrefixLength(v6, 128))
+}
+
+func validSPFNetwork(mechanism, arg string) bool {
+	value, ok := strings.CutPrefix(arg, ":")
+	if !ok {
+		return false
+	}
+
+	addr, prefix, hasPrefix := strings.Cut(value, "/")
+
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	bits := 32
+	if mechanism == "ip6" {
+		bits = 128
+		if !strings.Contains(addr, ":") {
+			return false
+		}
+	} else if ip.To4() == nil {
+		return false
+	}
+
+	return !hasPrefix || validPrefixLength(prefix, bits)
+}
+
+func validPrefixLength(s string, max int) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && s[0] != '+' && s[0] != '-' && n <= max
+}
+
+func checkDMARC(ctx context.Context, r *net.Resolver, domain string) models.DMARCDetails {
+	details := models.DMARCDetails{Percent: 100}
+
+	name := "_dmarc." + domain
+
+	records, err := lookupDMARCRecords(ctx, r, name)
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+
+	// Subdomains without a record of their own inherit the one published at
+	// the organizational domain (RFC 7489, section 6.6.3).
+	if len(records) == 0 {
+		org, err := publicsuffix.EffectiveTLDPlusOne(domain)
+		if err == nil && !strings.EqualFold(org, domain) {
+			orgName := "_dmarc." + org
+
+			records, err = lookupDMARCRecords(ctx, r, orgName)
+			if err != nil {
+				details.Error = err.Error()
+				return details
+			}
+			if len(records) == 0 {
+				details.Error = fmt.Sprintf("no record at %s or %s", name, orgName)
+				return details
+			}
+
+			name = orgName
+			details.OrganizationalDomain = org
+		}
+	}
+
+	switch len(records) {
+	case 0:
+		details.Error = fmt.Sprintf("no record at %s", name)
+		return details
+	case 1:
+		details.Record = records[0]
+	default:
+		details.Error = fmt.Sprintf("%d records at %s, only one is allowed", len(records), name)
+		return details
+	}
+
+	tags, err := parseTagList(details.Record)
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+
+	policy, ok := tags["p"]
+	if !ok {
+		details.Error = "missing p= tag"
+		return details
+	}
+
+	details.Policy = strings.ToLower(policy)
+	if _, ok := dmarcPolicyStrength[details.Policy]; !ok {
+		details.Error = fmt.Sprintf("invalid policy p=%s", policy)
+		return details
+	}
+
+	if sp, ok := tags["sp"]; ok {
+		details.SubdomainPolicy = strings.ToLower(sp)
+		if _, ok := dmarcPolicyStrength[details.SubdomainPolicy]; !ok {
+			details.Error = fmt.Sprintf("invalid subdomain policy sp=%s", sp)
+			return details
+		}
+	}
+
+	if pct, ok := tags["pct"]; ok {
+		n, err := strconv.Atoi(pct)
+		if err != nil || n < 0 || n > 100 {
+			details.Error = fmt.Sprintf("invalid pct=%s", pct)
+			return details
+		}
+		details.Percent = n
+	}
+
+	for _, tag := range []string{"rua", "ruf"} {
+		uris, ok := tags[tag]
+		if !ok {
+			continue
+		}
+
+		for _, uri := range strings.Split(uris, ",") {
+			uri = strings.TrimSpace(uri)
+			if scheme, rest, ok := strings.Cut(uri, ":"); !ok || scheme == "" || rest == "" {
+				details.Error = fmt.Sprintf("invalid %s URI '%s'", tag, uri)
+				return details
+			}
+		}
+	}
+
+	return details
+}
+
+// lookupDMARCRecords returns the DMARC records among the TXT records at name.
+func lookupDMARCRecords(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	txts, err := lookupTXTRecords(ctx, r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []string
+	for _, txt := range txts {
+		version, _, _ := strings.Cut(txt, ";")
+		if strings.EqualFold(strings.TrimSpace(version), "v=DMARC1") {
+			records = append(records, strings.TrimSpace(txt))
+		}
+	}
+
+	return records, nil
+}
+
+func checkDKIM(ctx context.Context, r *net.Resolver, domain, selector string) models.DKIMDetails {
+	details := models.DKIMDetails{Selector: selector}
+
+	name := selector + "._domainkey." + domain
+
+	txts, err := lookupTXTRecords(ctx, r, name)
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+
+	switch len(txts) {
+	case 0:
+		details.Error = fmt.Sprintf("no key at %s", name)
+		return details
+	case 1:
+	default:
+		details.Error = fmt.Sprintf("%d records at %s, only one is allowed", len(txts), name)
+		return details
+	}
+
+	tags, err := parseTagList(txts[0])
+	if err != nil {
+		details.Error = err.Error()
+		return details
+	}
+
+	if version, ok := tags["v"]; ok && version != "DKIM1" {
+		details.Error = fmt.Sprintf("unsupported version v=%s", version)
+		return details
+	}
+
+	details.KeyType = "rsa"
+	if k, ok := tags["k"]; ok {
+		details.KeyType = strings.ToLower(k)
+	}
+
+	key, ok := tags["p"]
+	if !ok {
+		details.Error = "missing p= tag"
+		return details
+	}
+
+	key = strings.Join(strings.Fields(key), "")
+	if key == "" {
+		details.Error = "key has been revoked (empty p=)"
+		return details
+	}
+
+	der, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		details.Error = "public key is not valid base64"
+		return details
+	}
+
+	switch details.KeyType {
+	case "rsa":
+		bits, err := rsaKeyBits(der)
+		if err != nil {
+			details.Error = "invalid RSA public key"
+			return details
+		}
+		details.KeyBits = bits
+
+		if bits < MinDKIMKeyBits {
+			details.Error = fmt.Sprintf("%d-bit RSA key is too short (minimum %d)", bits, MinDKIMKeyBits)
+		}
+
+	case "ed25519":
+		if len(der) != ed25519.PublicKeySize {
+			details.Error = "invalid Ed25519 public key"
+			return details
+		}
+		details.KeyBits = 256
+
+	default:
+		details.Error = fmt.Sprintf("unsupported key type k=%s", details.KeyType)
+	}
+
+	return details
+}
+
+// rsaKeyBits accepts the SubjectPublicKeyInfo form the RFC requires as well
+// as the bare PKCS #1 keys some providers publish.
+func rsaKeyBits(der []byte) (int, error) {
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return 0, errors.New("not an RSA key")
+		}
+		return rsaKey.N.BitLen(), nil
+	}
+
+	key, err := x509.ParsePKCS1PublicKey(der)
+	if err != nil {
+		return 0, err
+	}
+	return key.N.BitLen(), nil
+}
+
+// parseTagList parses the "tag=value; tag=value" syntax shared by DKIM and
+// DMARC records.
+func parseTagList(record string) (map[string]string, error) {
+	tags := make(map[string]string)
+
+	for _, spec := range strings.Split(record, ";") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed tag '%s'", truncate(spec, 40))
+		}
+
+		name = strings.TrimSpace(name)
+		if _, exists := tags[name]; exists {
+			return nil, fmt.Errorf("duplicate tag '%s='", name)
+		}
+		tags[name] = strings.TrimSpace(value)
+	}
+
+	return tags, nil
+}
+
+// lookupTXTRecords returns the TXT records at name, treating a missing name
+// as having none.
+func lookupTXTRecords(ctx context.Context, r *net.Resolver, name string) ([]string, error) {
+	txts, err := r.LookupTXT(ctx, name)
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+
+	return txts, err
+}
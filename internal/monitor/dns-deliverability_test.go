@@ -0,0 +1,249 @@
+package monitor
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startDNSServer serves zone, keyed by "TYPE name." (e.g. "TXT example.com."),
+// and returns a resolver that sends every query to it. Names missing from the
+// zone answer NXDOMAIN.
+func startDNSServer(t *testing.T, zone map[string][]string) *net.Resolver {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	names := make(map[string]bool)
+	for key := range zone {
+		_, name, _ := strings.Cut(key, " ")
+		names[name] = true
+	}
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var p dnsmessage.Parser
+			header, err := p.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			q, err := p.Question()
+			if err != nil {
+				continue
+			}
+
+			resp := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true, RecursionDesired: header.RecursionDesired, RecursionAvailable: true},
+				Questions: []dnsmessage.Question{q},
+			}
+
+			name := strings.ToLower(q.Name.String())
+			if !names[name] {
+				resp.Header.RCode = dnsmessage.RCodeNameError
+			}
+
+			rrHeader := dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: 60}
+			for _, value := range zone[strings.TrimPrefix(q.Type.String(), "Type")+" "+name] {
+				switch q.Type {
+				case dnsmessage.TypeTXT:
+					rrHeader.Type = dnsmessage.TypeTXT
+					resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: rrHeader, Body: &dnsmessage.TXTResource{TXT: []string{value}}})
+				case dnsmessage.TypeA:
+					rrHeader.Type = dnsmessage.TypeA
+					resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: rrHeader, Body: &dnsmessage.AResource{A: [4]byte(net.ParseIP(value).To4())}})
+				}
+			}
+
+			packed, err := resp.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	server := conn.LocalAddr().String()
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", server)
+		},
+	}
+}
+
+func TestCheckSPF(t *testing.T) {
+	r := startDNSServer(t, map[string][]string{
+		"TXT strict.example.com.":    {"v=spf1 ip4:192.0.2.0/24 ip6:2001:db8::/32 -all", "google-site-verification=abc"},
+		"TXT include.example.com.":   {"v=spf1 include:_spf.example.net ~all"},
+		"TXT _spf.example.net.":      {"v=spf1 ip4:198.51.100.1 a mx -all"},
+		"TXT redirect.example.com.":  {"v=spf1 redirect=_spf.example.net"},
+		"TXT open.example.com.":      {"v=spf1 +all"},
+		"TXT twice.example.com.":     {"v=spf1 -all", "v=spf1 ~all"},
+		"TXT none.example.com.":      {"some other record"},
+		"TXT loop.example.com.":      {"v=spf1 include:loop.example.com -all"},
+		"TXT costly.example.com.":    {"v=spf1 a mx ptr a:a.example.com a:b.example.com mx:c.example.com exists:d.example.com a mx ptr a -all"},
+		"TXT badip.example.com.":     {"v=spf1 ip4:300.1.1.1 -all"},
+		"TXT unknown.example.com.":   {"v=spf1 foo -all"},
+		"TXT bareinc.example.com.":   {"v=spf1 include -all"},
+		"TXT brokeninc.example.com.": {"v=spf1 include:missing.example.net -all"},
+		"TXT macro.example.com.":     {"v=spf1 include:%{i}._spf.example.net ?all"},
+	})
+
+	tests := []struct {
+		domain      string
+		wantAll     string
+		wantLookups int
+		wantErr     string
+	}{
+		{domain: "strict.example.com", wantAll: "-all"},
+		{domain: "include.example.com", wantAll: "~all", wantLookups: 3},
+		{domain: "redirect.example.com", wantAll: "-all", wantLookups: 3},
+		{domain: "open.example.com", wantAll: "+all", wantErr: "allows any sender"},
+		{domain: "twice.example.com", wantErr: "2 SPF records published"},
+		{domain: "none.example.com", wantErr: "no SPF record"},
+		{domain: "missing.example.com", wantErr: "no SPF record"},
+		{domain: "loop.example.com", wantLookups: 11, wantErr: "too many DNS lookups"},
+		{domain: "costly.example.com", wantLookups: 11, wantErr: "too many DNS lookups"},
+		{domain: "badip.example.com", wantErr: "invalid address in 'ip4:300.1.1.1'"},
+		{domain: "unknown.example.com", wantErr: "unknown mechanism 'foo'"},
+		{domain: "bareinc.example.com", wantErr: "'include' requires a domain"},
+		{domain: "brokeninc.example.com", wantLookups: 1, wantErr: "include:missing.example.net: no SPF record"},
+		{domain: "macro.example.com", wantAll: "?all", wantLookups: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			got := checkSPF(context.Background(), r, tt.domain)
+
+			if tt.wantErr == "" && got.Error != "" || !strings.Contains(got.Error, tt.wantErr) {
+				t.Fatalf("error = %q, want %q", got.Error, tt.wantErr)
+			}
+			if got.All != tt.wantAll {
+				t.Fatalf("all = %q, want %q", got.All, tt.wantAll)
+			}
+			if got.Lookups != tt.wantLookups {
+				t.Fatalf("lookups = %d, want %d", got.Lookups, tt.wantLookups)
+			}
+		})
+	}
+}
+
+func TestSPFTermSyntax(t *testing.T) {
+	modifiers := []struct {
+		term string
+		ok   bool
+	}{
+		{term: "redirect=_spf.example.com", ok: true},
+		{term: "exp=explain.example.com", ok: true},
+		{term: "x-custom.v1=anything", ok: true},
+		{term: "include:a=b.example.com", ok: false},
+		{term: "=value", ok: false},
+		{term: "1bad=value", ok: false},
+		{term: "ip4:192.0.2.1", ok: false},
+	}
+	for _, tt := range modifiers {
+		if _, _, ok := spfModifier(tt.term); ok != tt.ok {
+			t.Errorf("spfModifier(%q) ok = %v, want %v", tt.term, ok, tt.ok)
+		}
+	}
+
+	domainCIDRs := []struct {
+		arg string
+		ok  bool
+	}{
+		{arg: "", ok: true},
+		{arg: ":mail.example.com", ok: true},
+		{arg: "/24", ok: true},
+		{arg: "//64", ok: true},
+		{arg: ":example.com/24//64", ok: true},
+		{arg: ":", ok: false},
+		{arg: "/33", ok: false},
+		{arg: "//129", ok: false},
+		{arg: "/-1", ok: false},
+		{arg: "example.com", ok: false},
+	}
+	for _, tt := range domainCIDRs {
+		if ok := validSPFDomainCIDR(tt.arg); ok != tt.ok {
+			t.Errorf("validSPFDomainCIDR(%q) = %v, want %v", tt.arg, ok, tt.ok)
+		}
+	}
+
+	networks := []struct {
+		mechanism, arg string
+		ok             bool
+	}{
+		{mechanism: "ip4", arg: ":192.0.2.1", ok: true},
+		{mechanism: "ip4", arg: ":192.0.2.0/24", ok: true},
+		{mechanism: "ip6", arg: ":2001:db8::/32", ok: true},
+		{mechanism: "ip4", arg: "192.0.2.1", ok: false},
+		{mechanism: "ip4", arg: ":192.0.2.0/33", ok: false},
+		{mechanism: "ip4", arg: ":2001:db8::1", ok: false},
+		{mechanism: "ip6", arg: ":192.0.2.1", ok: false},
+		{mechanism: "ip6", arg: ":2001:db8::/+32", ok: false},
+	}
+	for _, tt := range networks {
+		if ok := validSPFNetwork(tt.mechanism, tt.arg); ok != tt.ok {
+			t.Errorf("validSPFNetwork(%q, %q) = %v, want %v", tt.mechanism, tt.arg, ok, tt.ok)
+		}
+	}
+}
+
+func TestCheckDMARC(t *testing.T) {
+	r := startDNSServer(t, map[string][]string{
+		"TXT _dmarc.strict.com.":    {"v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:dmarc@strict.com"},
+		"TXT _dmarc.example.co.uk.": {"v=DMARC1; p=quarantine"},
+		"TXT _dmarc.badpolicy.com.": {"v=DMARC1; p=block"},
+		"TXT _dmarc.nopolicy.com.":  {"v=DMARC1; rua=mailto:x@nopolicy.com"},
+		"TXT _dmarc.badpct.com.":    {"v=DMARC1; p=none; pct=150"},
+		"TXT _dmarc.badrua.com.":    {"v=DMARC1; p=none; rua=dmarc@badrua.com"},
+		"TXT _dmarc.twice.com.":     {"v=DMARC1; p=none", "v=DMARC1; p=reject"},
+		"TXT _dmarc.duptag.com.":    {"v=DMARC1; p=none; p=reject"},
+	})
+
+	tests := []struct {
+		domain      string
+		wantPolicy  string
+		wantSub     string
+		wantPercent int
+		wantOrg     string
+		wantErr     string
+	}{
+		{domain: "strict.com", wantPolicy: "reject", wantSub: "quarantine", wantPercent: 50},
+		{domain: "mail.example.co.uk", wantPolicy: "quarantine", wantPercent: 100, wantOrg: "example.co.uk"},
+		{domain: "missing.com", wantPercent: 100, wantErr: "no record at _dmarc.missing.com"},
+		{domain: "mail.missing.com", wantPercent: 100, wantErr: "no record at _dmarc.mail.missing.com or _dmarc.missing.com"},
+		{domain: "badpolicy.com", wantPolicy: "block", wantPercent: 100, wantErr: "invalid policy p=block"},
+		{domain: "nopolicy.com", wantPercent: 100, wantErr: "missing p= tag"},
+		{domain: "badpct.com", wantPolicy: "none", wantPercent: 100, wantErr: "invalid pct=150"},
+		{domain: "badrua.com", wantPolicy: "none", wantPercent: 100, wantErr: "invalid rua URI"},
+		{domain: "twice.com", wantPercent: 100, wantErr: "2 records at _dmarc.twice.com"},
+		{domain: "duptag.com", wantPercent: 100, wantErr: "duplicate tag 'p='"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			got := checkDMARC(context.Background(), r, tt.domain)
+
+			if tt.wantErr == "" && got.Error != "" || !strings.Contains(got.Error, tt.wantErr) {
+				t.Fatalf("error = %q, want %q", got.Error, tt.wantErr)
+			}
+			if got.Policy != tt.wantPolicy || got.SubdomainPolicy != tt.wantSub || got.Percent != tt.wantPercent || got.OrganizationalDomain != tt.wantOrg {
+				t.Fatalf("got %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const DefaultResolver = "8.8.8.8:53"
+
+// dnsResolver is a net.Resolver pinned to a single upstream server, which may
+// be plain DNS ("10.0.0.53", "udp://10.0.0.53:53"), DNS over TLS
+// ("tls://1.1.1.1:853") or DNS over HTTPS ("https://dns.google/dns-query").
+type dnsResolver struct {
+	Address  string
+	Resolver *net.Resolver
+
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+func newResolver(spec string, timeout time.Duration) (*dnsResolver, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("empty resolver address")
+	}
+
+	if !strings.Contains(spec, "://") {
+		spec = "udp://" + spec
+	}
+
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resolver '%s': %w", spec, err)
+	}
+
+	var dial func(ctx context.Context, network, address string) (net.Conn, error)
+
+	switch u.Scheme {
+	case "udp", "tcp":
+		addr := withDefaultPort(u.Host, "53")
+		dial = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			if u.Scheme == "tcp" {
+				network = "tcp"
+			}
+			d := net.Dialer{Timeout: timeout}
+			return d.DialContext(ctx, network, addr)
+		}
+
+	case "tls":
+		addr := withDefaultPort(u.Host, "853")
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			d := tls.Dialer{
+				NetDialer: &net.Dialer{Timeout: timeout},
+				Config:    &tls.Config{ServerName: u.Hostname()},
+			}
+			return d.DialContext(ctx, "tcp", addr)
+		}
+
+	case "https", "http":
+		client := &http.Client{Timeout: timeout}
+		endpoint := u.String()
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return &dohConn{ctx: ctx, client: client, endpoint: endpoint}, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported resolver scheme '%s'", u.Scheme)
+	}
+
+	return &dnsResolver{
+		Address:  strings.TrimPrefix(spec, "udp://"),
+		Resolver: &net.Resolver{PreferGo: true, Dial: dial},
+		dial:     dial,
+	}, nil
+}
+
+func withDefaultPort(host, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), port)
+}
+
+// exchange sends a single question to the resolver and returns the answer
+// section. It is used for record types net.Resolver does not expose.
+func (r *dnsResolver) exchange(ctx context.Context, name string, qtype dnsmessage.Type) ([]dnsmessage.Resource, error) {
+	query, id, err := buildQuery(name, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := r.roundTrip(ctx, "udp", query, id)
+	if err == nil && msg.Truncated {
+		msg, err = r.roundTrip(ctx, "tcp", query, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch msg.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return nil, fmt.Errorf("lookup %s: no such host", name)
+	default:
+		return nil, fmt.Errorf("lookup %s: server returned %s", name, msg.RCode)
+	}
+
+	var answers []dnsmessage.Resource
+	for _, rr := range msg.Answers {
+		if rr.Header.Type == qtype {
+			answers = append(answers, rr)
+		}
+	}
+
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("lookup %s: no %s records found", name, typeName(qtype))
+	}
+
+	return answers, nil
+}
+
+func (r *dnsResolver) roundTrip(ctx context.Context, network string, query []byte, id uint16) (*dnsmessage.Message, error) {
+	conn, err := r.dial(ctx, network, "")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	var raw []byte
+
+	if _, isPacket := conn.(net.PacketConn); isPacket {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+
+		buf := make([]byte, 4096)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		raw = buf[:n]
+	} else {
+		framed := make([]byte, 2+len(query))
+		binary.BigEndian.PutUint16(framed, uint16(len(query)))
+		copy(framed[2:], query)
+
+		if _, err := conn.Write(framed); err != nil {
+			return nil, err
+		}
+
+		var size [2]byte
+		if _, err := io.ReadFull(conn, size[:]); err != nil {
+			return nil, err
+		}
+
+		raw = make([]byte, binary.BigEndian.Uint16(size[:]))
+		if _, err := io.ReadFull(conn, raw); err != nil {
+			return nil, err
+		}
+	}
+
+	var msg dnsmessage.Message
+	if err := msg.Unpack(raw); err != nil {
+		return nil, fmt.Errorf("invalid DNS response: %w", err)
+	}
+
+	if msg.ID != id {
+		return nil, fmt.Errorf("DNS response ID mismatch")
+	}
+
+	return &msg, nil
+}
+
+func buildQuery(name string, qtype dnsmessage.Type) ([]byte, uint16, error) {
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+
+	qname, err := dnsmessage.NewName(name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	id := uint16(rand.Uint32())
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	b.EnableCompression()
+
+	if err := b.StartQuestions(); err != nil {
+		return nil, 0, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, 0, err
+	}
+
+	if err := b.StartAdditionals(); err != nil {
+		return nil, 0, err
+	}
+
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(4096, dnsmessage.RCodeSuccess, false); err != nil {
+		return nil, 0, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, 0, err
+	}
+
+	query, err := b.Finish()
+	return query, id, err
+}
+
+// dohConn adapts DNS over HTTPS to the stream framing the Go resolver uses
+// for TCP: queries are written with a two byte length prefix and answers are
+// read back the same way.
+type dohConn struct {
+	ctx      context.Context
+	client   *http.Client
+	endpoint string
+
+	query    bytes.Buffer
+	response *bytes.Reader
+}
+
+func (c *dohConn) Write(b []byte) (int, error) {
+	return c.query.Write(b)
+}
+
+func (c *dohConn) Read(b []byte) (int, error) {
+	if c.response == nil {
+		if err := c.roundTrip(); err != nil {
+			return 0, err
+		}
+	}
+	return c.response.Read(b)
+}
+
+func (c *dohConn) roundTrip() error {
+	framed := c.query.Bytes()
+	if len(framed) < 2 {
+		return fmt.Errorf("doh: empty query")
+	}
+
+	size := int(binary.BigEndian.Uint16(framed))
+	if len(framed) < 2+size {
+		return fmt.Errorf("doh: truncated query")
+	}
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodPost, c.endpoint, bytes.NewReader(framed[2:2+size]))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("doh: server returned %s", resp.Status)
+	}
+
+	answer, err := io.ReadAll(io.LimitReader(resp.Body, 65535))
+	if err != nil {
+		return err
+	}
+
+	out := make([]byte, 2+len(answer))
+	binary.BigEndian.PutUint16(out, uint16(len(answer)))
+	copy(out[2:], answer)
+
+	c.response = bytes.NewReader(out)
+	return nil
+}
+
+func (c *dohConn) Close() error                       { return nil }
+func (c *dohConn) LocalAddr() net.Addr                { return &net.TCPAddr{} }
+func (c *dohConn) RemoteAddr() net.Addr               { return &net.TCPAddr{} }
+func (c *dohConn) SetDeadline(t time.Time) error      { return nil }
+func (c *dohConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *dohConn) SetWriteDeadline(t time.Time) error { return nil }
@@ -0,0 +1,266 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+var DefaultDNSBLZones = []string{"zen.spamhaus.org", "bl.spamcop.net"}
+
+// publicResolvers are the well-known open resolvers; Spamhaus refuses every
+// query that reaches it through one of them.
+var publicResolvers = map[string]bool{
+	"8.8.8.8": true, "8.8.4.4": true, "2001:4860:4860::8888": true, "2001:4860:4860::8844": true, "dns.google": true,
+	"1.1.1.1": true, "1.0.0.1": true, "2606:4700:4700::1111": true, "2606:4700:4700::1001": true, "cloudflare-dns.com": true, "one.one.one.one": true,
+	"9.9.9.9": true, "149.112.112.112": true, "2620:fe::fe": true, "2620:fe::9": true, "dns.quad9.net": true,
+	"208.67.222.222": true, "208.67.220.220": true, "doh.opendns.com": true,
+}
+
+// dnsblRefused is the range blocklists such as Spamhaus answer with when
+// they refuse a query (e.g. from a public resolver) rather than list us.
+var dnsblRefused = net.IPNet{IP: net.IPv4(127, 255, 255, 0), Mask: net.CIDRMask(24, 32)}
+
+// checkDNSBL looks up every address of the target (a comma separated list of
+// IPs or hostnames) in every configured blocklist zone.
+func checkDNSBL(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.DNSBLConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] DNSBL configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	zones := config.Zones
+	if len(zones) == 0 {
+		zones = DefaultDNSBLZones
+	}
+
+	// Without an explicit resolver the system one is used: blocklists answer
+	// it, while the public resolvers are refused.
+	resolver := net.DefaultResolver
+	if config.Resolver != "" {
+		r, err := newResolver(config.Resolver, m.Timeout)
+		if err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: err.Error(), CheckedAt: time.Now()}
+		}
+		resolver = r.Resolver
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	addresses, err := dnsblAddresses(ctx, resolver, m.Target)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   err.Error(),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	details := models.DNSBLDetails{
+		Zones:   make([]string, 0, len(zones)),
+		Results: make([]models.DNSBLResult, 0, len(addresses)*len(zones)),
+	}
+
+	for _, zone := range zones {
+		details.Zones = append(details.Zones, strings.Trim(strings.TrimSpace(zone), "."))
+	}
+
+	for _, ip := range addresses {
+		details.Addresses = append(details.Addresses, ip.String())
+		for _, zone := range details.Zones {
+			details.Results = append(details.Results, models.DNSBLResult{Address: ip.String(), Zone: zone})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range details.Results {
+		wg.Add(1)
+		go func(res *models.DNSBLResult) {
+			defer wg.Done()
+			queryDNSBL(ctx, resolver, res)
+		}(&details.Results[i])
+	}
+	wg.Wait()
+
+	latency := time.Since(start).Milliseconds()
+
+	listedZones := make(map[string]bool)
+	var listings, failures []string
+	for _, res := range details.Results {
+		if res.Listed {
+			listedZones[res.Zone] = true
+			listings = append(listings, fmt.Sprintf("%s on %s", res.Address, res.Zone))
+		} else if res.Error != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", res.Zone, res.Error))
+		}
+	}
+
+	zonesListing := make([]string, 0, len(listedZones))
+	for zone := range listedZones {
+		zonesListing = append(zonesListing, zone)
+	}
+	sort.Strings(zonesListing)
+
+	status := models.StatusUp
+	message := fmt.Sprintf("%d address(es) not listed on %d blocklist(s)", len(details.Addresses), len(details.Zones))
+
+	switch {
+	case len(listings) > 0:
+		status = models.StatusDown
+		message = fmt.Sprintf("Listed on %s (%s)", strings.Join(zonesListing, ", "), strings.Join(listings, "; "))
+	case len(failures) == len(details.Results):
+		status = models.StatusDown
+		message = fmt.Sprintf("Could not query any blocklist: %s", failures[0])
+	case len(failures) > 0:
+		status = models.StatusDegraded
+		message = fmt.Sprintf("%d/%d blocklist queries failed: %s", len(failures), len(details.Results), strings.Join(failures, "; "))
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     latency,
+		ResultValue: strings.Join(zonesListing, ", "),
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// dnsblAddresses parses the target list, resolving hostnames to all of their
+// addresses.
+func dnsblAddresses(ctx context.Context, r *net.Resolver, target string) ([]net.IP, error) {
+	var addresses []net.IP
+	seen := make(map[string]bool)
+
+	add := func(ip net.IP) {
+		if !seen[ip.String()] {
+			seen[ip.String()] = true
+			addresses = append(addresses, ip)
+		}
+	}
+
+	for _, host := range strings.Split(target, ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+
+		if ip := net.ParseIP(host); ip != nil {
+			add(ip)
+			continue
+		}
+
+		ips, err := r.LookupIP(ctx, "ip", host)
+		if err != nil {
+			return nil, fmt.Errorf("Could not resolve %s: %s", host, err.Error())
+		}
+		for _, ip := range ips {
+			add(ip)
+		}
+	}
+
+	if len(addresses) == 0 {
+		return nil, errors.New("No addresses to check")
+	}
+
+	return addresses, nil
+}
+
+// queryDNSBL looks the address up in the zone. An NXDOMAIN answer means it is
+// not listed; the TXT record of a listing usually explains why.
+func queryDNSBL(ctx context.Context, r *net.Resolver, res *models.DNSBLResult) {
+	name := reverseAddress(net.ParseIP(res.Address)) + "." + res.Zone + "."
+
+	ips, err := r.LookupIP(ctx, "ip4", name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			res.Error = err.Error()
+		}
+		return
+	}
+
+	for _, ip := range ips {
+		switch {
+		case dnsblRefused.Contains(ip):
+			res.Error = fmt.Sprintf("query refused (%s)", ip)
+			return
+		case !ip.IsLoopback():
+			res.Error = fmt.Sprintf("unexpected answer %s", ip)
+			return
+		}
+		res.Codes = append(res.Codes, ip.String())
+	}
+
+	sort.Strings(res.Codes)
+	res.Listed = true
+
+	if txts, err := r.LookupTXT(ctx, name); err == nil {
+		res.Reason = truncate(strings.Join(txts, " "), 200)
+	}
+}
+
+// isPublicResolver reports whether the resolver spec points at one of the
+// well-known public resolvers.
+func isPublicResolver(spec string) bool {
+	spec = strings.TrimSpace(spec)
+	if ip := net.ParseIP(spec); ip != nil {
+		return publicResolvers[ip.String()]
+	}
+
+	if !strings.Contains(spec, "://") {
+		spec = "udp://" + spec
+	}
+
+	u, err := url.Parse(spec)
+	if err != nil {
+		return false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); ip != nil {
+		host = ip.String()
+	}
+
+	return publicResolvers[host]
+}
+
+// isSpamhausZone reports whether the zone is one of the Spamhaus blocklists.
+func isSpamhausZone(zone string) bool {
+	zone = strings.ToLower(strings.Trim(strings.TrimSpace(zone), "."))
+	return zone == "spamhaus.org" || strings.HasSuffix(zone, ".spamhaus.org")
+}
+
+// reverseAddress returns the label sequence blocklists are queried with:
+// reversed octets for IPv4 and reversed nibbles for IPv6.
+func reverseAddress(ip net.IP) string {
+	if v4 := ip.To4(); v4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d", v4[3], v4[2], v4[1], v4[0])
+	}
+
+	const hexDigits = "0123456789abcdef"
+
+	labels := make([]string, 0, 32)
+	for i := len(ip) - 1; i >= 0; i-- {
+		labels = append(labels, string(hexDigits[ip[i]&0x0f]), string(hexDigits[ip[i]>>4]))
+	}
+
+	return strings.Join(labels, ".")
+}
@@ -0,0 +1,150 @@
+package monitor
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestReverseAddress(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{ip: "192.0.2.1", want: "1.2.0.192"},
+		{ip: "::ffff:192.0.2.1", want: "1.2.0.192"},
+		{ip: "2001:db8::1", want: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		if got := reverseAddress(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("reverseAddress(%s) = %s, want %s", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestIsPublicResolver(t *testing.T) {
+	tests := []struct {
+		spec string
+		want bool
+	}{
+		{spec: "8.8.8.8", want: true},
+		{spec: " 1.1.1.1 ", want: true},
+		{spec: "9.9.9.9:53", want: true},
+		{spec: "[2606:4700:4700::1111]:53", want: true},
+		{spec: "2001:4860:4860:0:0:0:0:8888", want: true},
+		{spec: "tcp://208.67.222.222", want: true},
+		{spec: "https://DNS.Google/dns-query", want: true},
+		{spec: "tls://one.one.one.one", want: true},
+		{spec: "10.0.0.53", want: false},
+		{spec: "udp://resolver.internal:53", want: false},
+		{spec: "https://doh.example.com/dns-query", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublicResolver(tt.spec); got != tt.want {
+			t.Errorf("isPublicResolver(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestIsSpamhausZone(t *testing.T) {
+	tests := []struct {
+		zone string
+		want bool
+	}{
+		{zone: "zen.spamhaus.org", want: true},
+		{zone: "ZEN.Spamhaus.org.", want: true},
+		{zone: "spamhaus.org", want: true},
+		{zone: "bl.spamcop.net", want: false},
+		{zone: "notspamhaus.org", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isSpamhausZone(tt.zone); got != tt.want {
+			t.Errorf("isSpamhausZone(%q) = %v, want %v", tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestDNSBLAddresses(t *testing.T) {
+	r := startDNSServer(t, map[string][]string{
+		"A mail.example.com.": {"192.0.2.1", "192.0.2.2"},
+	})
+
+	tests := []struct {
+		target  string
+		want    []string
+		wantErr string
+	}{
+		{target: "192.0.2.1, mail.example.com", want: []string{"192.0.2.1", "192.0.2.2"}},
+		{target: "2001:db8::25,,2001:db8::25", want: []string{"2001:db8::25"}},
+		{target: " , ", wantErr: "No addresses to check"},
+		{target: "missing.example.com", wantErr: "Could not resolve missing.example.com"},
+	}
+
+	for _, tt := range tests {
+		ips, err := dnsblAddresses(context.Background(), r, tt.target)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("dnsblAddresses(%q) error = %v, want %q", tt.target, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("dnsblAddresses(%q) error = %v", tt.target, err)
+			continue
+		}
+
+		var got []string
+		for _, ip := range ips {
+			got = append(got, ip.String())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("dnsblAddresses(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestQueryDNSBL(t *testing.T) {
+	r := startDNSServer(t, map[string][]string{
+		"A 2.2.0.192.bl.example.org.":                                           {"127.0.0.4", "127.0.0.2"},
+		"TXT 2.2.0.192.bl.example.org.":                                         {"Listed for spam, see https://bl.example.org"},
+		"A 3.2.0.192.bl.example.org.":                                           {"127.255.255.254"},
+		"A 4.2.0.192.bl.example.org.":                                           {"198.51.100.7"},
+		"A " + reverseAddress(net.ParseIP("2001:db8::25")) + ".bl.example.org.": {"127.0.0.10"},
+	})
+
+	tests := []struct {
+		address    string
+		wantListed bool
+		wantCodes  []string
+		wantReason string
+		wantErr    string
+	}{
+		{address: "192.0.2.1"},
+		{address: "192.0.2.2", wantListed: true, wantCodes: []string{"127.0.0.2", "127.0.0.4"}, wantReason: "Listed for spam"},
+		{address: "192.0.2.3", wantErr: "query refused (127.255.255.254)"},
+		{address: "192.0.2.4", wantErr: "unexpected answer 198.51.100.7"},
+		{address: "2001:db8::25", wantListed: true, wantCodes: []string{"127.0.0.10"}},
+	}
+
+	for _, tt := range tests {
+		res := models.DNSBLResult{Address: tt.address, Zone: "bl.example.org"}
+		queryDNSBL(context.Background(), r, &res)
+
+		if res.Listed != tt.wantListed || !reflect.DeepEqual(res.Codes, tt.wantCodes) {
+			t.Errorf("%s: listed = %v %v, want %v %v", tt.address, res.Listed, res.Codes, tt.wantListed, tt.wantCodes)
+		}
+		if !strings.Contains(res.Reason, tt.wantReason) {
+			t.Errorf("%s: reason = %q, want %q", tt.address, res.Reason, tt.wantReason)
+		}
+		if tt.wantErr == "" && res.Error != "" || !strings.Contains(res.Error, tt.wantErr) {
+			t.Errorf("%s: error = %q, want %q", tt.address, res.Error, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+const DefaultRDAPURL = "https://rdap.org"
+
+// Registry statuses (RFC 8056 mapped to RDAP) that mean the domain no longer
+// resolves or is about to be released.
+var criticalDomainStatuses = map[string]bool{
+	"client hold":       true,
+	"server hold":       true,
+	"redemption period": true,
+	"pending delete":    true,
+}
+
+type rdapDomain struct {
+	LDHName  string       `json:"ldhName"`
+	Status   []string     `json:"status"`
+	Events   []rdapEvent  `json:"events"`
+	Entities []rdapEntity `json:"entities"`
+}
+
+type rdapEvent struct {
+	Action string `json:"eventAction"`
+	Date   string `json:"eventDate"`
+}
+
+type rdapEntity struct {
+	Handle     string          `json:"handle"`
+	Roles      []string        `json:"roles"`
+	VCardArray json.RawMessage `json:"vcardArray"`
+}
+
+func checkDomain(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.DomainConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] Domain configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	server := strings.TrimSuffix(config.RDAPURL, "/")
+	if server == "" {
+		server = DefaultRDAPURL
+	}
+
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(m.Target)), ".")
+
+	start := time.Now()
+
+	client := &http.Client{Timeout: m.Timeout}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/domain/%s", server, domain), nil)
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid RDAP request: %s", err.Error()), CheckedAt: time.Now()}
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("RDAP query failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer resp.Body.Close()
+
+	latency := time.Since(start).Milliseconds()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   latency,
+			Message:   fmt.Sprintf("Domain %s is not registered", domain),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   latency,
+			Message:   fmt.Sprintf("RDAP server returned %s", resp.Status),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	var record rdapDomain
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxBodySize)).Decode(&record); err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   latency,
+			Message:   fmt.Sprintf("Invalid RDAP response: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	details := models.DomainDetails{
+		Registrar:  rdapRegistrar(record.Entities),
+		Status:     record.Status,
+		RDAPServer: server,
+	}
+
+	expiresAt, ok := rdapExpiration(record.Events)
+	if !ok {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   latency,
+			Message:   "RDAP response has no expiration date",
+			Details:   marshalDetails(details),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	details.ExpiresAt = expiresAt
+	details.DaysRemaining = int(time.Until(expiresAt).Hours() / 24)
+
+	status := models.StatusUp
+	message := fmt.Sprintf("Domain registered until %s (%d days)", expiresAt.Format("02/01/2006"), details.DaysRemaining)
+
+	var critical []string
+	for _, s := range record.Status {
+		if criticalDomainStatuses[strings.ToLower(s)] {
+			critical = append(critical, s)
+		}
+	}
+
+	if time.Until(expiresAt) < 0 {
+		status = models.StatusDown
+		message = fmt.Sprintf("CRITICAL: Domain expired %s (%d days ago)", expiresAt.Format("02/01/2006"), -details.DaysRemaining)
+	} else if len(critical) > 0 {
+		status = models.StatusDown
+		message = fmt.Sprintf("Domain status: %s", strings.Join(critical, ", "))
+	} else if details.DaysRemaining <= 30 {
+		status = models.StatusDegraded
+		message = fmt.Sprintf("Domain expires in %d days (%s)", details.DaysRemaining, expiresAt.Format("02/01/2006"))
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     latency,
+		Message:     message,
+		ResultValue: strconv.Itoa(details.DaysRemaining),
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+func rdapExpiration(events []rdapEvent) (time.Time, bool) {
+	for _, e := range events {
+		if e.Action != "expiration" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, e.Date)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+// rdapRegistrar returns the "fn" property of the registrar's vCard, falling
+// back to its handle.
+func rdapRegistrar(entities []rdapEntity) string {
+	for _, e := range entities {
+		isRegistrar := false
+		for _, role := range e.Roles {
+			if role == "registrar" {
+				isRegistrar = true
+			}
+		}
+
+		if !isRegistrar {
+			continue
+		}
+
+		// vcardArray is ["vcard", [[name, params, type, value], ...]]
+		var vcard []json.RawMessage
+		if json.Unmarshal(e.VCardArray, &vcard) == nil && len(vcard) == 2 {
+			var props [][]any
+			if json.Unmarshal(vcard[1], &props) == nil {
+				for _, prop := range props {
+					if len(prop) < 4 || prop[0] != "fn" {
+						continue
+					}
+					if name, ok := prop[3].(string); ok && name != "" {
+						return name
+					}
+				}
+			}
+		}
+
+		return e.Handle
+	}
+
+	return ""
+}
@@ -0,0 +1,105 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func checkGRPC(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.GRPCConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] gRPC configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		port := "80"
+		if config.TLS {
+			port = "443"
+		}
+		target = net.JoinHostPort(target, port)
+	}
+
+	creds := insecure.NewCredentials()
+	if config.TLS {
+		creds = credentials.NewTLS(&tls.Config{
+			ServerName:         config.ServerName,
+			InsecureSkipVerify: config.InsecureSkipVerify,
+		})
+	}
+
+	// passthrough dials the target as given, like the other checkers, instead
+	// of running it through a resolver.
+	conn, err := grpc.NewClient("passthrough:///"+target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid gRPC target: %s", err.Error()), CheckedAt: time.Now()}
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	if len(config.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(config.Metadata))
+	}
+
+	start := time.Now()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: config.Service})
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		st := grpcstatus.Convert(err)
+		details := models.GRPCDetails{GRPCStatus: int(st.Code()), GRPCMessage: st.Message()}
+
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   latency,
+			Message:   fmt.Sprintf("gRPC error %d (%s): %s", details.GRPCStatus, st.Code(), details.GRPCMessage),
+			Details:   marshalDetails(details),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	details := models.GRPCDetails{ServingStatus: resp.GetStatus().String()}
+
+	service := config.Service
+	if service == "" {
+		service = "server"
+	}
+
+	var status models.MonitorStatus
+	switch resp.GetStatus() {
+	case healthpb.HealthCheckResponse_SERVING:
+		status = models.StatusUp
+	case healthpb.HealthCheckResponse_UNKNOWN:
+		status = models.StatusDegraded
+	default:
+		status = models.StatusDown
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     latency,
+		ResultValue: details.ServingStatus,
+		Message:     fmt.Sprintf("Health of '%s': %s", service, details.ServingStatus),
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
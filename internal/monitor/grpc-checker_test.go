@@ -0,0 +1,100 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// startHealthServer runs a grpc-go health server that rejects calls without
+// the "authorization: token" metadata.
+func startHealthServer(t *testing.T) (*health.Server, string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if got := md.Get("authorization"); len(got) != 1 || got[0] != "token" {
+			return nil, grpcstatus.Error(codes.Unauthenticated, "missing token")
+		}
+		return handler(ctx, req)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(auth))
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	return healthServer, ln.Addr().String()
+}
+
+func TestCheckGRPC(t *testing.T) {
+	healthServer, addr := startHealthServer(t)
+	healthServer.SetServingStatus("billing", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus("search", healthpb.HealthCheckResponse_UNKNOWN)
+
+	token := map[string]string{"Authorization": "token"}
+
+	tests := []struct {
+		name       string
+		config     models.GRPCConfig
+		wantStatus models.MonitorStatus
+		wantValue  string
+		wantCode   int
+	}{
+		{name: "server serving", config: models.GRPCConfig{Metadata: token}, wantStatus: models.StatusUp, wantValue: "SERVING"},
+		{name: "service not serving", config: models.GRPCConfig{Service: "billing", Metadata: token}, wantStatus: models.StatusDown, wantValue: "NOT_SERVING"},
+		{name: "service unknown status", config: models.GRPCConfig{Service: "search", Metadata: token}, wantStatus: models.StatusDegraded, wantValue: "UNKNOWN"},
+		{name: "service not registered", config: models.GRPCConfig{Service: "missing", Metadata: token}, wantStatus: models.StatusDown, wantCode: int(codes.NotFound)},
+		{name: "metadata missing", config: models.GRPCConfig{}, wantStatus: models.StatusDown, wantCode: int(codes.Unauthenticated)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := json.Marshal(tt.config)
+			res := checkGRPC(context.Background(), models.Monitor{ID: 1, Target: addr, Config: config, Timeout: 2 * time.Second})
+
+			if res.Status != tt.wantStatus || res.ResultValue != tt.wantValue {
+				t.Fatalf("got %s %q (%s), want %s %q", res.Status, res.ResultValue, res.Message, tt.wantStatus, tt.wantValue)
+			}
+
+			var details models.GRPCDetails
+			if err := json.Unmarshal(res.Details, &details); err != nil {
+				t.Fatal(err)
+			}
+			if details.GRPCStatus != tt.wantCode {
+				t.Fatalf("grpc status = %d, want %d", details.GRPCStatus, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCheckGRPCUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	res := checkGRPC(context.Background(), models.Monitor{ID: 1, Target: addr, Timeout: time.Second})
+	if res.Status != models.StatusDown {
+		t.Fatalf("status = %s (%s), want down", res.Status, res.Message)
+	}
+}
@@ -0,0 +1,133 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/database"
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// heartbeatChecker evaluates a push monitor from the last pings received on
+// its heartbeat URL instead of contacting the target.
+type heartbeatChecker struct {
+	db *pgxpool.Pool
+}
+
+// RegisterHeartbeat makes heartbeat monitors available. They read the pings
+// stored by the API, so unlike the other types they are registered by the
+// process that owns the database handle.
+func RegisterHeartbeat(db *pgxpool.Pool) {
+	Register(models.TypeHeartbeat, heartbeatChecker{db: db})
+}
+
+func (heartbeatChecker) ValidateConfig(config json.RawMessage) error {
+	return validateHeartbeatConfig(config)
+}
+
+func (c heartbeatChecker) Check(ctx context.Context, mon models.Monitor) models.CheckResult {
+	var config models.HeartbeatConfig
+	if len(mon.Config) > 0 {
+		if err := json.Unmarshal(mon.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: mon.ID, Status: models.StatusDown, Message: "[ERROR] Heartbeat configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	var grace time.Duration
+	if config.GracePeriod != "" {
+		if d, err := time.ParseDuration(config.GracePeriod); err == nil {
+			grace = d
+		}
+	}
+
+	heartbeat, err := database.GetHeartbeatByMonitorID(ctx, c.db, mon.ID)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: mon.ID,
+			Status:    models.StatusUnknown,
+			Message:   fmt.Sprintf("Could not load heartbeat: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	return evaluateHeartbeat(mon, heartbeat, mon.Interval+grace, time.Now())
+}
+
+// evaluateHeartbeat judges the pings stored for a monitor: a reported
+// failure, a run that started but never finished, or no ping within window
+// take it down.
+func evaluateHeartbeat(mon models.Monitor, heartbeat *models.Heartbeat, window time.Duration, now time.Time) models.CheckResult {
+	if heartbeat.LastEvent == models.HeartbeatFail && heartbeat.LastEventAt != nil {
+		message := "Job reported a failure"
+		if heartbeat.LastMessage != "" {
+			message = fmt.Sprintf("Job reported a failure: %s", heartbeat.LastMessage)
+		}
+
+		return models.CheckResult{
+			MonitorID:   mon.ID,
+			Status:      models.StatusDown,
+			ResultValue: heartbeat.LastEventAt.Format(time.RFC3339),
+			Message:     message,
+			CheckedAt:   now,
+		}
+	}
+
+	if heartbeat.LastEvent == models.HeartbeatStart && heartbeat.LastStartAt != nil && now.Sub(*heartbeat.LastStartAt) > window {
+		return models.CheckResult{
+			MonitorID:   mon.ID,
+			Status:      models.StatusDown,
+			ResultValue: heartbeat.LastStartAt.Format(time.RFC3339),
+			Message:     fmt.Sprintf("Job started at %s but never finished", heartbeat.LastStartAt.Format("02/01/2006 15:04:05")),
+			CheckedAt:   now,
+		}
+	}
+
+	if heartbeat.LastPingAt == nil {
+		if now.Sub(mon.CreatedAt) > window {
+			return models.CheckResult{
+				MonitorID: mon.ID,
+				Status:    models.StatusDown,
+				Message:   "No ping received yet",
+				CheckedAt: now,
+			}
+		}
+
+		return models.CheckResult{
+			MonitorID: mon.ID,
+			Status:    models.StatusUnknown,
+			Message:   "Waiting for the first ping",
+			CheckedAt: now,
+		}
+	}
+
+	lastPing := *heartbeat.LastPingAt
+
+	if now.Sub(lastPing) > window {
+		return models.CheckResult{
+			MonitorID:   mon.ID,
+			Status:      models.StatusDown,
+			ResultValue: lastPing.Format(time.RFC3339),
+			Message:     fmt.Sprintf("No ping received since %s", lastPing.Format("02/01/2006 15:04:05")),
+			CheckedAt:   now,
+		}
+	}
+
+	// When the run announced its start, latency is how long it took. A start
+	// left over from an earlier run says nothing about this one.
+	var latency int64
+	if heartbeat.LastDurationMs != nil {
+		latency = *heartbeat.LastDurationMs
+	}
+
+	return models.CheckResult{
+		MonitorID:   mon.ID,
+		Status:      models.StatusUp,
+		Latency:     latency,
+		ResultValue: lastPing.Format(time.RFC3339),
+		Message:     fmt.Sprintf("Last ping at %s", lastPing.Format("02/01/2006 15:04:05")),
+		CheckedAt:   now,
+	}
+}
@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestEvaluateHeartbeat(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+	ms := func(v int64) *int64 { return &v }
+
+	mon := models.Monitor{ID: 1, Type: models.TypeHeartbeat, CreatedAt: now.Add(-48 * time.Hour)}
+	window := time.Hour
+
+	tests := []struct {
+		name        string
+		heartbeat   models.Heartbeat
+		wantStatus  models.MonitorStatus
+		wantLatency int64
+	}{
+		{
+			name:       "no ping yet",
+			heartbeat:  models.Heartbeat{},
+			wantStatus: models.StatusDown,
+		},
+		{
+			name:        "run with start",
+			heartbeat:   models.Heartbeat{LastEvent: models.HeartbeatPing, LastPingAt: at(time.Minute), LastStartAt: at(3 * time.Minute), LastDurationMs: ms(120000)},
+			wantStatus:  models.StatusUp,
+			wantLatency: 120000,
+		},
+		{
+			name:        "stale start from an earlier run",
+			heartbeat:   models.Heartbeat{LastEvent: models.HeartbeatPing, LastPingAt: at(time.Minute), LastStartAt: at(30 * time.Hour)},
+			wantStatus:  models.StatusUp,
+			wantLatency: 0,
+		},
+		{
+			name:       "ping overdue",
+			heartbeat:  models.Heartbeat{LastEvent: models.HeartbeatPing, LastPingAt: at(2 * time.Hour)},
+			wantStatus: models.StatusDown,
+		},
+		{
+			name:       "started but never finished",
+			heartbeat:  models.Heartbeat{LastEvent: models.HeartbeatStart, LastPingAt: at(30 * time.Minute), LastStartAt: at(90 * time.Minute)},
+			wantStatus: models.StatusDown,
+		},
+		{
+			name:       "reported failure",
+			heartbeat:  models.Heartbeat{LastEvent: models.HeartbeatFail, LastEventAt: at(time.Minute), LastPingAt: at(time.Minute)},
+			wantStatus: models.StatusDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := evaluateHeartbeat(mon, &tt.heartbeat, window, now)
+
+			if res.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", res.Status, res.Message, tt.wantStatus)
+			}
+			if res.Latency != tt.wantLatency {
+				t.Fatalf("latency = %d, want %d", res.Latency, tt.wantLatency)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+// MaxAssertionPreview caps how much of a response value is quoted back in an
+// assertion failure message.
+const MaxAssertionPreview = 120
+
+// evaluateAssertions runs every assertion against the response body and
+// returns the message of the first one that fails, or "" when all pass.
+func evaluateAssertions(assertions []models.HTTPAssertion, body []byte) string {
+	var doc any
+	var docErr error
+	decoded := false
+
+	for i, a := range assertions {
+		switch a.Type {
+		case models.AssertContains:
+			if !strings.Contains(string(body), a.Value) {
+				return fmt.Sprintf("Assertion #%d failed: body does not contain '%s'", i+1, a.Value)
+			}
+
+		case models.AssertNotContains:
+			if strings.Contains(string(body), a.Value) {
+				return fmt.Sprintf("Assertion #%d failed: body contains '%s'", i+1, a.Value)
+			}
+
+		case models.AssertRegex:
+			re, err := regexp.Compile(a.Value)
+			if err != nil {
+				return fmt.Sprintf("Assertion #%d failed: invalid regex '%s'", i+1, a.Value)
+			}
+
+			if !re.Match(body) {
+				return fmt.Sprintf("Assertion #%d failed: body does not match /%s/", i+1, a.Value)
+			}
+
+		case models.AssertJSONPath:
+			if !decoded {
+				docErr = json.Unmarshal(body, &doc)
+				decoded = true
+			}
+
+			if docErr != nil {
+				return fmt.Sprintf("Assertion #%d failed: response body is not valid JSON", i+1)
+			}
+
+			value, err := lookupJSONPath(doc, a.Path)
+			if err != nil {
+				return fmt.Sprintf("Assertion #%d failed: %s: %s", i+1, a.Path, err.Error())
+			}
+
+			if msg := compareJSONValue(value, a.Operator, a.Value); msg != "" {
+				return fmt.Sprintf("Assertion #%d failed: %s %s", i+1, a.Path, msg)
+			}
+
+		default:
+			return fmt.Sprintf("Assertion #%d failed: unknown assertion type '%s'", i+1, a.Type)
+		}
+	}
+
+	return ""
+}
+
+// compareJSONValue applies operator to the value found at a JSONPath and
+// returns a description of the mismatch, or "" when the comparison holds.
+func compareJSONValue(value any, operator, expected string) string {
+	actual := jsonValueString(value)
+
+	if operator == "" {
+		operator = "eq"
+	}
+
+	switch operator {
+	case "eq":
+		if actual != expected {
+			return fmt.Sprintf("expected '%s', found '%s'", expected, assertionPreview(actual))
+		}
+		return ""
+	case "ne":
+		if actual == expected {
+			return fmt.Sprintf("expected anything but '%s'", expected)
+		}
+		return ""
+	case "exists":
+		return ""
+	}
+
+	actualNum, ok := value.(float64)
+	if !ok {
+		return fmt.Sprintf("value '%s' is not a number", assertionPreview(actual))
+	}
+
+	expectedNum, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return fmt.Sprintf("expected value '%s' is not a number", expected)
+	}
+
+	var holds bool
+	switch operator {
+	case "gt":
+		holds = actualNum > expectedNum
+	case "gte":
+		holds = actualNum >= expectedNum
+	case "lt":
+		holds = actualNum < expectedNum
+	case "lte":
+		holds = actualNum <= expectedNum
+	default:
+		return fmt.Sprintf("unknown operator '%s'", operator)
+	}
+
+	if !holds {
+		return fmt.Sprintf("expected %s %s, found %s", operator, expected, actual)
+	}
+
+	return ""
+}
+
+// assertionPreview prepares a value taken from the response for a failure
+// message: control characters are escaped and long values are cut short.
+func assertionPreview(s string) string {
+	if !isPrintableText([]byte(s)) {
+		s = strings.Trim(strconv.Quote(s), `"`)
+	}
+	return truncate(s, MaxAssertionPreview)
+}
@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestEvaluateAssertions(t *testing.T) {
+	body := []byte(`{"status":"ok","count":3,"items":[{"id":"a"}],"note":"bad\u0000byte","blob":"` + strings.Repeat("x", 500) + `"}`)
+
+	tests := []struct {
+		name      string
+		assertion models.HTTPAssertion
+		want      string
+	}{
+		{name: "contains", assertion: models.HTTPAssertion{Type: models.AssertContains, Value: `"ok"`}},
+		{name: "contains missing", assertion: models.HTTPAssertion{Type: models.AssertContains, Value: "down"}, want: "body does not contain 'down'"},
+		{name: "not contains", assertion: models.HTTPAssertion{Type: models.AssertNotContains, Value: "ok"}, want: "body contains 'ok'"},
+		{name: "regex", assertion: models.HTTPAssertion{Type: models.AssertRegex, Value: `"count":\d+`}},
+		{name: "regex mismatch", assertion: models.HTTPAssertion{Type: models.AssertRegex, Value: `^<html`}, want: "does not match"},
+		{name: "json eq", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.status", Value: "ok"}},
+		{name: "json ne", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.status", Operator: "ne", Value: "ok"}, want: "expected anything but 'ok'"},
+		{name: "json gte", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.count", Operator: "gte", Value: "3"}},
+		{name: "json lt", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.count", Operator: "lt", Value: "3"}, want: "expected lt 3, found 3"},
+		{name: "json index", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.items[0].id", Value: "a"}},
+		{name: "json missing key", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.missing", Operator: "exists"}, want: "key 'missing' not found"},
+		{name: "json not a number", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.status", Operator: "gt", Value: "1"}, want: "value 'ok' is not a number"},
+		{name: "control bytes escaped", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.note", Value: "good"}, want: `found 'bad\x00byte'`},
+		{name: "long value cut", assertion: models.HTTPAssertion{Type: models.AssertJSONPath, Path: "$.blob", Value: "y"}, want: strings.Repeat("x", MaxAssertionPreview) + "...'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateAssertions([]models.HTTPAssertion{tt.assertion}, body)
+
+			if tt.want == "" {
+				if got != "" {
+					t.Fatalf("unexpected failure: %s", got)
+				}
+				return
+			}
+
+			if !strings.Contains(got, tt.want) {
+				t.Fatalf("failure = %q, want it to contain %q", got, tt.want)
+			}
+			if strings.ContainsRune(got, 0) {
+				t.Fatalf("failure %q contains a NUL byte", got)
+			}
+		})
+	}
+}
+
+func TestEvaluateAssertionsInvalidJSON(t *testing.T) {
+	got := evaluateAssertions([]models.HTTPAssertion{{Type: models.AssertJSONPath, Path: "$.status"}}, []byte("<html>"))
+	if !strings.Contains(got, "not valid JSON") {
+		t.Fatalf("failure = %q", got)
+	}
+}
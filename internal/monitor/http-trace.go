@@ -0,0 +1,104 @@
+package monitor
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+// httpTimer collects the phase breakdown of a request through httptrace.
+// Phases are summed across redirect hops so the totals add up to the full
+// request time.
+type httpTimer struct {
+	mu sync.Mutex
+
+	dnsStart     time.Time
+	connectStart map[string]time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+
+	dns, connect, tlsHandshake, ttfb time.Duration
+}
+
+func newHTTPTimer() *httpTimer {
+	return &httpTimer{connectStart: make(map[string]time.Time)}
+}
+
+func (t *httpTimer) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.dnsStart.IsZero() {
+				t.dns += time.Since(t.dnsStart)
+			}
+		},
+		ConnectStart: func(network, addr string) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.connectStart[addr] = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if started, ok := t.connectStart[addr]; ok && err == nil {
+				t.connect += time.Since(started)
+			}
+			delete(t.connectStart, addr)
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			if !t.tlsStart.IsZero() {
+				t.tlsHandshake += time.Since(t.tlsStart)
+			}
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.wroteRequest = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.firstByte = time.Now()
+			if !t.wroteRequest.IsZero() {
+				t.ttfb += t.firstByte.Sub(t.wroteRequest)
+			}
+		},
+	}
+}
+
+// timing returns the collected phases, measuring the content transfer from
+// the first response byte until bodyDone.
+func (t *httpTimer) timing(bodyDone time.Time) *models.HTTPTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := &models.HTTPTiming{
+		DNSLookup:    t.dns.Milliseconds(),
+		TCPConnect:   t.connect.Milliseconds(),
+		TLSHandshake: t.tlsHandshake.Milliseconds(),
+		TTFB:         t.ttfb.Milliseconds(),
+	}
+
+	if !t.firstByte.IsZero() && !bodyDone.IsZero() {
+		timing.Transfer = bodyDone.Sub(t.firstByte).Milliseconds()
+	}
+
+	return timing
+}
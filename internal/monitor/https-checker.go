@@ -1,9 +1,12 @@
 package monitor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptrace"
 	"strconv"
 	"strings"
 	"time"
@@ -11,19 +14,74 @@ import (
 	"github.com/ghduuep/pingly/internal/models"
 )
 
-func checkHTTP(m models.Monitor) models.CheckResult {
+const (
+	MaxBodySize         = 1 << 20
+	DefaultMaxRedirects = 10
+)
+
+func checkHTTP(ctx context.Context, m models.Monitor) models.CheckResult {
 	var config models.HTTPConfig
 	if len(m.Config) > 0 {
 		_ = json.Unmarshal(m.Config, &config)
 	}
 
+	acceptedCodes, err := parseStatusRanges(config.AcceptedStatusCodes)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Invalid accepted status codes: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	followRedirects := config.FollowRedirects == nil || *config.FollowRedirects
+
+	maxRedirects := config.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+
+	var details models.HTTPDetails
+
 	client := http.Client{
 		Timeout: m.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			prev := via[len(via)-1]
+			details.RedirectChain = append(details.RedirectChain, models.RedirectHop{
+				URL:        prev.URL.String(),
+				StatusCode: req.Response.StatusCode,
+				Location:   req.URL.String(),
+			})
+
+			if !followRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return nil
+		},
 	}
 
+	req, err := buildHTTPRequest(m.Target, config)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Invalid HTTP request configuration: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	timer := newHTTPTimer()
+	req = req.WithContext(httptrace.WithClientTrace(ctx, timer.trace()))
+
 	start := time.Now()
 
-	resp, err := client.Get(m.Target)
+	resp, err := client.Do(req)
 	latency := time.Since(start).Milliseconds()
 
 	if err != nil {
@@ -33,20 +91,47 @@ func checkHTTP(m models.Monitor) models.CheckResult {
 			message = "Connection Timeout"
 		}
 
+		details.Timing = timer.timing(time.Time{})
+
 		return models.CheckResult{
 			MonitorID: m.ID,
 			Status:    models.StatusDown,
 			Latency:   latency,
 			Message:   message,
+			Details:   marshalDetails(details),
 			CheckedAt: time.Now(),
 		}
 	}
 	defer resp.Body.Close()
 
+	if len(details.RedirectChain) > 0 && followRedirects {
+		details.RedirectChain = append(details.RedirectChain, models.RedirectHop{
+			URL:        resp.Request.URL.String(),
+			StatusCode: resp.StatusCode,
+		})
+	}
+
 	message := resp.Status
 	status := models.StatusDown
-	if resp.StatusCode >= 200 && resp.StatusCode < 500 {
+	if statusAccepted(acceptedCodes, resp.StatusCode) {
 		status = models.StatusUp
+	} else if location := resp.Header.Get("Location"); location != "" {
+		message = fmt.Sprintf("Unexpected status %s (redirect to %s)", resp.Status, location)
+	} else {
+		message = fmt.Sprintf("Unexpected status %s", resp.Status)
+	}
+
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	details.Timing = timer.timing(time.Now())
+
+	if status == models.StatusUp && len(config.Assertions) > 0 {
+		if readErr != nil {
+			status = models.StatusDown
+			message = fmt.Sprintf("Failed to read response body: %s", readErr.Error())
+		} else if failure := evaluateAssertions(config.Assertions, body); failure != "" {
+			status = models.StatusDown
+			message = failure
+		}
 	}
 
 	var resultValue string
@@ -61,7 +146,7 @@ func checkHTTP(m models.Monitor) models.CheckResult {
 		if expiresIn < 0 {
 			status = models.StatusDown
 			message = fmt.Sprintf("CRITICAL: SSL certificate expired %s (%d days ago)", cert.NotAfter.Format("02/01/2006"), -days)
-		} else if days <= 30 {
+		} else if days <= 30 && status == models.StatusUp {
 			status = models.StatusDegraded
 			message = fmt.Sprintf("SSl expires in %d days (%s)", days, cert.NotAfter.Format("02/01/2006"))
 		}
@@ -74,6 +159,99 @@ func checkHTTP(m models.Monitor) models.CheckResult {
 		Message:     message,
 		StatusCode:  resp.StatusCode,
 		ResultValue: resultValue,
+		Details:     marshalDetails(details),
 		CheckedAt:   time.Now(),
 	}
 }
+
+// parseStatusRanges turns a spec like "200-299,301" into inclusive ranges.
+// An empty spec accepts 200-399, so client errors such as 401 or 404 are not
+// mistaken for a healthy service.
+func parseStatusRanges(spec string) ([][2]int, error) {
+	if strings.TrimSpace(spec) == "" {
+		return [][2]int{{200, 399}}, nil
+	}
+
+	var ranges [][2]int
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		lowStr, highStr, isRange := strings.Cut(part, "-")
+		if !isRange {
+			highStr = lowStr
+		}
+
+		low, err := strconv.Atoi(strings.TrimSpace(lowStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid status code '%s'", part)
+		}
+
+		high, err := strconv.Atoi(strings.TrimSpace(highStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid status code '%s'", part)
+		}
+
+		if low < 100 || high > 599 || low > high {
+			return nil, fmt.Errorf("invalid status range '%s'", part)
+		}
+
+		ranges = append(ranges, [2]int{low, high})
+	}
+
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("no status codes given")
+	}
+
+	return ranges, nil
+}
+
+func statusAccepted(ranges [][2]int, code int) bool {
+	for _, r := range ranges {
+		if code >= r[0] && code <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func buildHTTPRequest(target string, config models.HTTPConfig) (*http.Request, error) {
+	method := strings.ToUpper(strings.TrimSpace(config.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if config.Body != "" {
+		body = strings.NewReader(config.Body)
+	}
+
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range config.Headers {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+
+	if config.Auth != nil {
+		switch config.Auth.Type {
+		case models.AuthBasic:
+			req.SetBasicAuth(config.Auth.Username, config.Auth.Password)
+		case models.AuthBearer:
+			req.Header.Set("Authorization", "Bearer "+config.Auth.Token)
+		default:
+			return nil, fmt.Errorf("unknown auth type '%s'", config.Auth.Type)
+		}
+	}
+
+	return req, nil
+}
@@ -0,0 +1,65 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestParseStatusRanges(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    [][2]int
+		wantErr bool
+	}{
+		{spec: "", want: [][2]int{{200, 399}}},
+		{spec: "200-299,301", want: [][2]int{{200, 299}, {301, 301}}},
+		{spec: " 200 - 204 , 404 ", want: [][2]int{{200, 204}, {404, 404}}},
+		{spec: "299-200", wantErr: true},
+		{spec: "99", wantErr: true},
+		{spec: "200-600", wantErr: true},
+		{spec: "abc", wantErr: true},
+		{spec: ",", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseStatusRanges(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseStatusRanges(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("parseStatusRanges(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestCheckHTTPDefaultRejectsClientErrors(t *testing.T) {
+	tests := []struct {
+		code int
+		want models.MonitorStatus
+	}{
+		{code: http.StatusOK, want: models.StatusUp},
+		{code: http.StatusNotModified, want: models.StatusUp},
+		{code: http.StatusUnauthorized, want: models.StatusDown},
+		{code: http.StatusNotFound, want: models.StatusDown},
+		{code: http.StatusBadGateway, want: models.StatusDown},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.code)
+		}))
+
+		res := checkHTTP(context.Background(), models.Monitor{Target: srv.URL, Timeout: 2 * time.Second})
+		srv.Close()
+
+		if res.Status != tt.want {
+			t.Fatalf("status %d: got %s (%s), want %s", tt.code, res.Status, res.Message, tt.want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	DefaultPingCount = 4
+	MaxPingCount     = 20
+)
+
+func checkICMP(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.PingConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] ICMP configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	count := config.Count
+	if count <= 0 {
+		count = DefaultPingCount
+	}
+	if count > MaxPingCount {
+		count = MaxPingCount
+	}
+
+	addr, err := net.ResolveIPAddr("ip", m.Target)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("DNS error: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	conn, privileged, err := listenICMP(addr.IP.To4() == nil)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Could not open ICMP socket: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer conn.Close()
+	defer closeOnCancel(ctx, conn)()
+
+	var dst net.Addr = addr
+	if !privileged {
+		dst = &net.UDPAddr{IP: addr.IP, Zone: addr.Zone}
+	}
+
+	// Raw sockets see every echo reply on the host, so each check picks
+	// its own identifier to tell its replies apart from concurrent checks.
+	id := rand.IntN(0x10000)
+	perPacket := m.Timeout / time.Duration(count)
+
+	var rtts []time.Duration
+
+	for seq := 1; seq <= count && ctx.Err() == nil; seq++ {
+		rtt, err := sendEcho(conn, dst, addr.IP.To4() == nil, privileged, id, seq, perPacket)
+		if err == nil {
+			rtts = append(rtts, rtt)
+		}
+	}
+
+	details := pingStats(count, rtts)
+
+	if details.Received == 0 {
+		return models.CheckResult{
+			MonitorID:   m.ID,
+			Status:      models.StatusDown,
+			ResultValue: fmt.Sprintf("%.1f%% loss", details.PacketLoss),
+			Message:     fmt.Sprintf("Host unreachable (%d packets sent, 0 received)", count),
+			Details:     marshalDetails(details),
+			CheckedAt:   time.Now(),
+		}
+	}
+
+	status := models.StatusUp
+	message := fmt.Sprintf("%d/%d packets received", details.Received, details.Sent)
+
+	if config.DegradedLoss > 0 && details.PacketLoss >= config.DegradedLoss {
+		status = models.StatusDegraded
+		message = fmt.Sprintf("Packet loss %.1f%% (Limit: %.1f%%)", details.PacketLoss, config.DegradedLoss)
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     int64(math.Round(details.AvgRTT)),
+		ResultValue: fmt.Sprintf("%.1f%% loss", details.PacketLoss),
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// listenICMP prefers unprivileged datagram sockets and falls back to raw
+// sockets when the kernel does not allow them for this process.
+func listenICMP(isIPv6 bool) (*icmp.PacketConn, bool, error) {
+	udpNetwork, rawNetwork, address := "udp4", "ip4:icmp", "0.0.0.0"
+	if isIPv6 {
+		udpNetwork, rawNetwork, address = "udp6", "ip6:ipv6-icmp", "::"
+	}
+
+	conn, err := icmp.ListenPacket(udpNetwork, address)
+	if err == nil {
+		return conn, false, nil
+	}
+
+	conn, rawErr := icmp.ListenPacket(rawNetwork, address)
+	if rawErr != nil {
+		return nil, false, err
+	}
+
+	return conn, true, nil
+}
+
+func sendEcho(conn *icmp.PacketConn, dst net.Addr, isIPv6, privileged bool, id, seq int, timeout time.Duration) (time.Duration, error) {
+	var msgType, replyType icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	protocol := 1
+	if isIPv6 {
+		msgType, replyType = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+		protocol = 58
+	}
+
+	msg := icmp.Message{
+		Type: msgType,
+		Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("pingly")},
+	}
+
+	payload, err := msg.Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(payload, dst); err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 1500)
+
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, err
+		}
+
+		if !sameHost(peer, dst) {
+			continue
+		}
+
+		reply, err := icmp.ParseMessage(protocol, buf[:n])
+		if err != nil || reply.Type != replyType {
+			continue
+		}
+
+		echo, ok := reply.Body.(*icmp.Echo)
+		if !ok || echo.Seq != seq {
+			continue
+		}
+
+		// Datagram sockets rewrite the identifier, so it is only
+		// meaningful on raw sockets.
+		if privileged && echo.ID != id {
+			continue
+		}
+
+		return time.Since(start), nil
+	}
+}
+
+func sameHost(a, b net.Addr) bool {
+	return addrIP(a).Equal(addrIP(b))
+}
+
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.IPAddr:
+		return a.IP
+	case *net.UDPAddr:
+		return a.IP
+	default:
+		return nil
+	}
+}
+
+func pingStats(sent int, rtts []time.Duration) models.PingDetails {
+	details := models.PingDetails{
+		Sent:       sent,
+		Received:   len(rtts),
+		PacketLoss: float64(sent-len(rtts)) * 100 / float64(sent),
+	}
+
+	if len(rtts) == 0 {
+		return details
+	}
+
+	var sum, jitterSum float64
+	details.MinRTT = math.MaxFloat64
+
+	for i, rtt := range rtts {
+		ms := float64(rtt.Microseconds()) / 1000
+
+		sum += ms
+		details.MinRTT = math.Min(details.MinRTT, ms)
+		details.MaxRTT = math.Max(details.MaxRTT, ms)
+
+		if i > 0 {
+			jitterSum += math.Abs(ms - float64(rtts[i-1].Microseconds())/1000)
+		}
+	}
+
+	details.AvgRTT = roundMillis(sum / float64(len(rtts)))
+
+	if len(rtts) > 1 {
+		details.Jitter = roundMillis(jitterSum / float64(len(rtts)-1))
+	}
+
+	return details
+}
+
+func roundMillis(ms float64) float64 {
+	return math.Round(ms*1000) / 1000
+}
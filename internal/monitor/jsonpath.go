@@ -0,0 +1,109 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// lookupJSONPath resolves a simple JSONPath expression such as
+// "$.data.items[0].status" or "$['status']" against a decoded JSON document.
+func lookupJSONPath(doc any, path string) (any, error) {
+	path = strings.TrimSpace(path)
+	path = strings.TrimPrefix(path, "$")
+
+	current := doc
+
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+
+			key := path[:end]
+			path = path[end:]
+
+			if key == "" {
+				return nil, fmt.Errorf("empty key in path")
+			}
+
+			obj, ok := current.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("'%s' is not an object", key)
+			}
+
+			value, exists := obj[key]
+			if !exists {
+				return nil, fmt.Errorf("key '%s' not found", key)
+			}
+			current = value
+
+		case '[':
+			end := strings.Index(path, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed bracket in path")
+			}
+
+			token := path[1:end]
+			path = path[end+1:]
+
+			if quoted := strings.Trim(token, `'"`); quoted != token {
+				obj, ok := current.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("'%s' is not an object", quoted)
+				}
+
+				value, exists := obj[quoted]
+				if !exists {
+					return nil, fmt.Errorf("key '%s' not found", quoted)
+				}
+				current = value
+				continue
+			}
+
+			index, err := strconv.Atoi(token)
+			if err != nil {
+				return nil, fmt.Errorf("invalid index '%s'", token)
+			}
+
+			arr, ok := current.([]any)
+			if !ok {
+				return nil, fmt.Errorf("index [%d] used on a non-array value", index)
+			}
+
+			if index < 0 {
+				index += len(arr)
+			}
+
+			if index < 0 || index >= len(arr) {
+				return nil, fmt.Errorf("index [%s] out of range", token)
+			}
+			current = arr[index]
+
+		default:
+			return nil, fmt.Errorf("unexpected character '%c' in path", path[0])
+		}
+	}
+
+	return current, nil
+}
+
+// jsonValueString renders a decoded JSON value the way users write it in
+// configs: strings unquoted, everything else in its JSON form.
+func jsonValueString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return "null"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,81 @@
+package monitor
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLookupJSONPath(t *testing.T) {
+	var doc any
+	body := `{
+		"status": "ok",
+		"data": {"items": [{"id": 1, "tags": ["a", "b"]}, {"id": 2, "ready": true}], "count": 2.5},
+		"weird.key": {"x y": null}
+	}`
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "$.status", want: "ok"},
+		{path: " $.data.count ", want: "2.5"},
+		{path: "$.data.items[0].id", want: "1"},
+		{path: "$.data.items[-1].ready", want: "true"},
+		{path: "$.data.items[0].tags", want: `["a","b"]`},
+		{path: "$['weird.key'][\"x y\"]", want: "null"},
+		{path: "$.data.items[1]", want: `{"id":2,"ready":true}`},
+		{path: "$.missing", wantErr: "key 'missing' not found"},
+		{path: "$.status.inner", wantErr: "'inner' is not an object"},
+		{path: "$.data.items[2]", wantErr: "index [2] out of range"},
+		{path: "$.data.items[-3]", wantErr: "index [-3] out of range"},
+		{path: "$.data.items[x]", wantErr: "invalid index 'x'"},
+		{path: "$.status[0]", wantErr: "non-array value"},
+		{path: "$.data.items[0", wantErr: "unclosed bracket"},
+		{path: "$..status", wantErr: "empty key"},
+		{path: "$status", wantErr: "unexpected character 's'"},
+	}
+
+	for _, tt := range tests {
+		got, err := lookupJSONPath(doc, tt.path)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("lookupJSONPath(%q) error = %v, want %q", tt.path, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("lookupJSONPath(%q) error = %v", tt.path, err)
+			continue
+		}
+
+		if s := jsonValueString(got); s != tt.want {
+			t.Errorf("lookupJSONPath(%q) = %s, want %s", tt.path, s, tt.want)
+		}
+	}
+}
+
+func TestJSONValueString(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{value: "text", want: "text"},
+		{value: nil, want: "null"},
+		{value: float64(42), want: "42"},
+		{value: 0.1, want: "0.1"},
+		{value: 1e21, want: "1000000000000000000000"},
+		{value: true, want: "true"},
+		{value: map[string]any{"a": float64(1)}, want: `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		if got := jsonValueString(tt.value); got != tt.want {
+			t.Errorf("jsonValueString(%v) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+// Default ports for plain (or STARTTLS) and implicit TLS connections.
+var defaultMailPorts = map[models.MonitorType][2]string{
+	models.TypeSMTP: {"25", "465"},
+	models.TypeIMAP: {"143", "993"},
+	models.TypePOP3: {"110", "995"},
+}
+
+func checkMail(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.MailConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] Mail configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	protocol := string(m.Type)
+	label := strings.ToUpper(protocol)
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		port := defaultMailPorts[m.Type][0]
+		if config.TLS {
+			port = defaultMailPorts[m.Type][1]
+		}
+		target = net.JoinHostPort(target, port)
+	}
+
+	host, _, _ := net.SplitHostPort(target)
+
+	start := time.Now()
+
+	var conn net.Conn
+	var err error
+
+	if config.TLS {
+		dialer := tls.Dialer{NetDialer: &net.Dialer{Timeout: m.Timeout}, Config: &tls.Config{ServerName: host}}
+		conn, err = dialer.DialContext(ctx, "tcp", target)
+	} else {
+		dialer := net.Dialer{Timeout: m.Timeout}
+		conn, err = dialer.DialContext(ctx, "tcp", target)
+	}
+
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Connection failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer conn.Close()
+	defer closeOnCancel(ctx, conn)()
+
+	_ = conn.SetDeadline(time.Now().Add(m.Timeout))
+
+	fail := func(details models.MailDetails, format string, args ...any) models.CheckResult {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf(format, args...),
+			Details:   marshalDetails(details),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	client := newMailClient(conn, protocol)
+	defer client.quit()
+
+	var details models.MailDetails
+
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		details.TLSVersion = tls.VersionName(tlsConn.ConnectionState().Version)
+	}
+
+	details.Banner, err = client.greeting()
+	if err != nil {
+		return fail(details, "%s server rejected connection: %s", label, err.Error())
+	}
+
+	if config.BannerContains != "" && !strings.Contains(details.Banner, config.BannerContains) {
+		return fail(details, "Banner does not contain '%s'", config.BannerContains)
+	}
+
+	details.Capabilities, err = client.capabilities()
+	if err != nil {
+		return fail(details, "Capability request failed: %s", err.Error())
+	}
+
+	if config.StartTLS && !config.TLS {
+		if !client.hasStartTLS(details.Capabilities) {
+			return fail(details, "Server does not advertise STARTTLS")
+		}
+
+		if err := client.startTLS(); err != nil {
+			return fail(details, "STARTTLS refused: %s", err.Error())
+		}
+
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: host})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fail(details, "STARTTLS handshake failed: %s", err.Error())
+		}
+
+		details.TLSVersion = tls.VersionName(tlsConn.ConnectionState().Version)
+		client.setConn(tlsConn)
+
+		// Servers commonly advertise AUTH only once the session is encrypted.
+		details.Capabilities, err = client.capabilities()
+		if err != nil {
+			return fail(details, "Capability request after STARTTLS failed: %s", err.Error())
+		}
+	}
+
+	details.AuthMechanisms = client.authMechanisms(details.Capabilities)
+
+	for _, required := range config.AuthMechanisms {
+		if !containsFold(details.AuthMechanisms, required) {
+			return fail(details, "AUTH mechanism %s not offered", strings.ToUpper(required))
+		}
+	}
+
+	message := fmt.Sprintf("%s ready: %s", label, details.Banner)
+	if details.TLSVersion != "" {
+		message = fmt.Sprintf("%s ready over %s: %s", label, details.TLSVersion, details.Banner)
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      models.StatusUp,
+		Latency:     time.Since(start).Milliseconds(),
+		ResultValue: details.Banner,
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// mailClient drives the greeting, capability and STARTTLS exchanges of
+// SMTP, IMAP and POP3 sessions.
+type mailClient struct {
+	protocol string
+	conn     net.Conn
+	reader   *textproto.Reader
+	tag      int
+}
+
+func newMailClient(conn net.Conn, protocol string) *mailClient {
+	c := &mailClient{protocol: protocol}
+	c.setConn(conn)
+	return c
+}
+
+func (c *mailClient) setConn(conn net.Conn) {
+	c.conn = conn
+	c.reader = textproto.NewReader(bufio.NewReader(conn))
+}
+
+func (c *mailClient) send(format string, args ...any) error {
+	_, err := fmt.Fprintf(c.conn, format+"\r\n", args...)
+	return err
+}
+
+func (c *mailClient) nextTag() string {
+	c.tag++
+	return fmt.Sprintf("a%d", c.tag)
+}
+
+// greeting reads the server banner. 4xx/5xx SMTP codes, IMAP BYE and POP3
+// -ERR greetings are returned as errors.
+func (c *mailClient) greeting() (string, error) {
+	switch c.protocol {
+	case "smtp":
+		code, msg, err := c.reader.ReadResponse(220)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d %s", code, firstLine(msg)), nil
+
+	case "imap":
+		line, err := c.reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+
+		if !strings.HasPrefix(line, "* OK") && !strings.HasPrefix(line, "* PREAUTH") {
+			return "", fmt.Errorf("unexpected greeting: %s", line)
+		}
+		return line, nil
+
+	case "pop3":
+		line, err := c.reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+
+		if !strings.HasPrefix(line, "+OK") {
+			return "", fmt.Errorf("unexpected greeting: %s", line)
+		}
+		return line, nil
+
+	default:
+		return "", fmt.Errorf("unsupported protocol '%s'", c.protocol)
+	}
+}
+
+func (c *mailClient) capabilities() ([]string, error) {
+	switch c.protocol {
+	case "smtp":
+		if err := c.send("EHLO pingly"); err != nil {
+			return nil, err
+		}
+
+		_, msg, err := c.reader.ReadResponse(250)
+		if err != nil {
+			return nil, fmt.Errorf("EHLO: %w", err)
+		}
+
+		// The first line is the server's greeting, not a capability.
+		lines := strings.Split(msg, "\n")
+		return lines[1:], nil
+
+	case "imap":
+		tag := c.nextTag()
+		if err := c.send("%s CAPABILITY", tag); err != nil {
+			return nil, err
+		}
+
+		var caps []string
+		for {
+			line, err := c.reader.ReadLine()
+			if err != nil {
+				return nil, err
+			}
+
+			if fields := strings.Fields(line); len(fields) > 2 && fields[0] == "*" && strings.EqualFold(fields[1], "CAPABILITY") {
+				caps = fields[2:]
+				continue
+			}
+
+			if strings.HasPrefix(line, tag+" ") {
+				if !strings.HasPrefix(line, tag+" OK") {
+					return nil, fmt.Errorf("CAPABILITY: %s", line)
+				}
+				return caps, nil
+			}
+		}
+
+	case "pop3":
+		if err := c.send("CAPA"); err != nil {
+			return nil, err
+		}
+
+		line, err := c.reader.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+
+		if !strings.HasPrefix(line, "+OK") {
+			return nil, fmt.Errorf("CAPA: %s", line)
+		}
+
+		return c.reader.ReadDotLines()
+
+	default:
+		return nil, fmt.Errorf("unsupported protocol '%s'", c.protocol)
+	}
+}
+
+func (c *mailClient) hasStartTLS(caps []string) bool {
+	keyword := "STARTTLS"
+	if c.protocol == "pop3" {
+		keyword = "STLS"
+	}
+
+	for _, capability := range caps {
+		if strings.EqualFold(strings.TrimSpace(capability), keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *mailClient) authMechanisms(caps []string) []string {
+	var mechanisms []string
+
+	for _, capability := range caps {
+		upper := strings.ToUpper(strings.TrimSpace(capability))
+
+		switch c.protocol {
+		case "smtp":
+			if strings.HasPrefix(upper, "AUTH ") || strings.HasPrefix(upper, "AUTH=") {
+				mechanisms = append(mechanisms, strings.Fields(upper[5:])...)
+			}
+		case "imap":
+			if strings.HasPrefix(upper, "AUTH=") {
+				mechanisms = append(mechanisms, upper[5:])
+			}
+		case "pop3":
+			if strings.HasPrefix(upper, "SASL ") {
+				mechanisms = append(mechanisms, strings.Fields(upper[5:])...)
+			}
+		}
+	}
+
+	return mechanisms
+}
+
+// startTLS asks the server to upgrade the connection. The caller performs the
+// TLS handshake once it returns.
+func (c *mailClient) startTLS() error {
+	switch c.protocol {
+	case "smtp":
+		if err := c.send("STARTTLS"); err != nil {
+			return err
+		}
+
+		if _, _, err := c.reader.ReadResponse(220); err != nil {
+			return fmt.Errorf("STARTTLS: %w", err)
+		}
+
+	case "imap":
+		tag := c.nextTag()
+		if err := c.send("%s STARTTLS", tag); err != nil {
+			return err
+		}
+
+		for {
+			line, err := c.reader.ReadLine()
+			if err != nil {
+				return fmt.Errorf("STARTTLS: %w", err)
+			}
+
+			if strings.HasPrefix(line, tag+" ") {
+				if !strings.HasPrefix(line, tag+" OK") {
+					return fmt.Errorf("server refused STARTTLS: %s", line)
+				}
+				break
+			}
+		}
+
+	case "pop3":
+		if err := c.send("STLS"); err != nil {
+			return err
+		}
+
+		line, err := c.reader.ReadLine()
+		if err != nil {
+			return fmt.Errorf("STLS: %w", err)
+		}
+
+		if !strings.HasPrefix(line, "+OK") {
+			return fmt.Errorf("server refused STLS: %s", line)
+		}
+
+	default:
+		return fmt.Errorf("unsupported protocol '%s'", c.protocol)
+	}
+
+	return nil
+}
+
+func (c *mailClient) quit() {
+	switch c.protocol {
+	case "smtp", "pop3":
+		_ = c.send("QUIT")
+	case "imap":
+		_ = c.send("%s LOGOUT", c.nextTag())
+	}
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
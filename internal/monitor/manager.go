@@ -17,6 +17,8 @@ const (
 	DownCheckInterval = 30 * time.Second
 	FailureThreshold  = 2
 	FlappingTTLMulti  = 3
+
+	HeartbeatPollInterval = time.Minute
 )
 
 type activeMonitor struct {
@@ -29,15 +31,24 @@ type MonitorManager struct {
 	redis          *redis.Client
 	dispatcher     notification.NotificationDispatcher
 	activeMonitors map[int]*activeMonitor
+
+	// notify delivers an alert to the monitor owner's channels; tests swap it
+	// to capture alerts without a database.
+	notify func(ctx context.Context, mon models.Monitor, res models.CheckResult, inc *models.Incident)
 }
 
 func NewMonitorManager(db *pgxpool.Pool, rdb *redis.Client, dispatcher notification.NotificationDispatcher) *MonitorManager {
-	return &MonitorManager{
+	m := &MonitorManager{
 		db:             db,
 		redis:          rdb,
 		dispatcher:     dispatcher,
 		activeMonitors: make(map[int]*activeMonitor),
 	}
+	m.notify = m.sendAlert
+
+	RegisterHeartbeat(db)
+
+	return m
 }
 
 func (m *MonitorManager) Start(ctx context.Context) {
@@ -119,11 +130,15 @@ func (m *MonitorManager) handleStateChange(ctx context.Context, mon *models.Moni
 	mon.StatusChangedAt = &res.CheckedAt
 
 	if incident != nil {
-		channels, _ := database.GetEnabledUserChannels(ctx, m.db, mon.UserID)
-		go m.dispatcher.SendAlert(channels, *mon, res, incident)
+		m.notify(ctx, *mon, res, incident)
 	}
 }
 
+func (m *MonitorManager) sendAlert(ctx context.Context, mon models.Monitor, res models.CheckResult, inc *models.Incident) {
+	channels, _ := database.GetEnabledUserChannels(ctx, m.db, mon.UserID)
+	go m.dispatcher.SendAlert(channels, mon, res, inc)
+}
+
 func (m *MonitorManager) stopAll() {
 	for _, active := range m.activeMonitors {
 		active.cancel()
@@ -0,0 +1,216 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	mysqlErrDBAccessDenied         = 1044
+	mysqlErrAccessDenied           = 1045
+	mysqlErrNotSupportedAuthMode   = 1251
+	mysqlErrAccessDeniedNoPassword = 1698
+	mysqlErrSecureTransportNeeded  = 3159
+)
+
+func checkMySQL(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.DatabaseConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] MySQL configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, "3306")
+	}
+
+	sslMode := config.SSLMode
+	if sslMode == "" {
+		sslMode = "prefer"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// Like libpq, allow tries a plain connection first and only switches to
+	// TLS when the server insists on it.
+	db, conn, err := connectMySQL(ctx, mysqlConfig(m, config, target, sslMode))
+	var serverErr *mysql.MySQLError
+	if sslMode == "allow" && errors.As(err, &serverErr) && serverErr.Number == mysqlErrSecureTransportNeeded {
+		db, conn, err = connectMySQL(ctx, mysqlConfig(m, config, target, "require"))
+	}
+
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   mysqlConnectMessage(err),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer db.Close()
+	defer conn.Close()
+
+	details := models.DatabaseDetails{ConnectTime: time.Since(start).Milliseconds()}
+	_ = conn.QueryRowContext(ctx, "SELECT VERSION()").Scan(&details.ServerVersion)
+
+	query := config.Query
+	if query == "" {
+		query = DefaultQuery
+	}
+
+	queryStart := time.Now()
+	value, err := mysqlQueryFirstValue(ctx, conn, query)
+	details.QueryTime = time.Since(queryStart).Milliseconds()
+
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   details.QueryTime,
+			Message:   fmt.Sprintf("Query failed: %s", err.Error()),
+			Details:   marshalDetails(details),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	return databaseResult(m, config, value, details)
+}
+
+// mysqlConfig builds the driver settings for a monitor, mapping ssl_mode onto
+// the libpq semantics the PostgreSQL checker gets from pgx.
+func mysqlConfig(m models.Monitor, config models.DatabaseConfig, target, sslMode string) *mysql.Config {
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = target
+	cfg.User = config.Username
+	cfg.Passwd = config.Password
+	cfg.DBName = config.Database
+	cfg.Timeout = m.Timeout
+	cfg.ReadTimeout = m.Timeout
+	cfg.WriteTimeout = m.Timeout
+	cfg.Logger = &mysql.NopLogger{}
+
+	host, _, _ := net.SplitHostPort(target)
+
+	switch sslMode {
+	case "disable", "allow":
+		cfg.TLSConfig = "false"
+	case "prefer":
+		cfg.TLSConfig = "preferred"
+	case "require":
+		cfg.TLS = &tls.Config{InsecureSkipVerify: true}
+	case "verify-ca":
+		cfg.TLS = &tls.Config{
+			InsecureSkipVerify: true,
+			VerifyConnection: func(state tls.ConnectionState) error {
+				return verifyChain(state.PeerCertificates, "")
+			},
+		}
+	default:
+		cfg.TLS = &tls.Config{ServerName: host}
+	}
+
+	return cfg
+}
+
+// connectMySQL opens a single connection so the caller can time the login
+// apart from the query.
+func connectMySQL(ctx context.Context, cfg *mysql.Config) (db *sql.DB, conn *sql.Conn, err error) {
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db = sql.OpenDB(connector)
+	db.SetMaxOpenConns(1)
+
+	// The driver indexes the server greeting without bounds checks, so a
+	// truncated one from a misbehaving target must not take the worker down.
+	defer func() {
+		if r := recover(); r != nil {
+			db.Close()
+			db, conn, err = nil, nil, fmt.Errorf("malformed server handshake: %v", r)
+		}
+	}()
+
+	conn, err = db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+
+	return db, conn, nil
+}
+
+// mysqlConnectMessage describes a failed login so credential problems are not
+// confused with network or protocol ones.
+func mysqlConnectMessage(err error) string {
+	var serverErr *mysql.MySQLError
+	if errors.As(err, &serverErr) {
+		switch serverErr.Number {
+		case mysqlErrDBAccessDenied, mysqlErrAccessDenied, mysqlErrNotSupportedAuthMode, mysqlErrAccessDeniedNoPassword:
+			return fmt.Sprintf("Authentication failed: %s", err.Error())
+		default:
+			return fmt.Sprintf("Server refused connection: %s", err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, mysql.ErrUnknownPlugin), errors.Is(err, mysql.ErrCleartextPassword),
+		errors.Is(err, mysql.ErrNativePassword), errors.Is(err, mysql.ErrOldPassword):
+		return fmt.Sprintf("Authentication failed: %s", err.Error())
+	default:
+		return fmt.Sprintf("Connection failed: %s", err.Error())
+	}
+}
+
+// mysqlQueryFirstValue runs query and returns the first column of the first
+// row, or "" when the query returns no rows.
+func mysqlQueryFirstValue(ctx context.Context, conn *sql.Conn, query string) (string, error) {
+	rows, err := conn.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	if rows.Next() && len(columns) > 0 {
+		var first sql.NullString
+		dest := make([]any, len(columns))
+		dest[0] = &first
+		for i := 1; i < len(dest); i++ {
+			dest[i] = new(sql.RawBytes)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return "", err
+		}
+
+		value = "NULL"
+		if first.Valid {
+			value = first.String
+		}
+	}
+
+	return value, rows.Err()
+}
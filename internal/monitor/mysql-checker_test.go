@@ -0,0 +1,167 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestMySQLConfigSSLModes(t *testing.T) {
+	mon := models.Monitor{Timeout: time.Second}
+	config := models.DatabaseConfig{Username: "root"}
+
+	tests := []struct {
+		mode       string
+		tlsConfig  string
+		wantTLS    bool
+		skipVerify bool
+		verifyCA   bool
+	}{
+		{mode: "disable", tlsConfig: "false"},
+		{mode: "allow", tlsConfig: "false"},
+		{mode: "prefer", tlsConfig: "preferred"},
+		{mode: "require", wantTLS: true, skipVerify: true},
+		{mode: "verify-ca", wantTLS: true, skipVerify: true, verifyCA: true},
+		{mode: "verify-full", wantTLS: true},
+	}
+
+	for _, tt := range tests {
+		cfg := mysqlConfig(mon, config, "db.example.com:3306", tt.mode)
+
+		if cfg.TLSConfig != tt.tlsConfig {
+			t.Errorf("%s: TLSConfig = %q, want %q", tt.mode, cfg.TLSConfig, tt.tlsConfig)
+		}
+		if (cfg.TLS != nil) != tt.wantTLS {
+			t.Errorf("%s: TLS set = %v, want %v", tt.mode, cfg.TLS != nil, tt.wantTLS)
+			continue
+		}
+		if cfg.TLS == nil {
+			continue
+		}
+		if cfg.TLS.InsecureSkipVerify != tt.skipVerify {
+			t.Errorf("%s: InsecureSkipVerify = %v, want %v", tt.mode, cfg.TLS.InsecureSkipVerify, tt.skipVerify)
+		}
+		if (cfg.TLS.VerifyConnection != nil) != tt.verifyCA {
+			t.Errorf("%s: chain verification = %v, want %v", tt.mode, cfg.TLS.VerifyConnection != nil, tt.verifyCA)
+		}
+		if !tt.skipVerify && cfg.TLS.ServerName != "db.example.com" {
+			t.Errorf("%s: ServerName = %q", tt.mode, cfg.TLS.ServerName)
+		}
+	}
+}
+
+func TestMySQLConnectMessage(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: &mysql.MySQLError{Number: 1045, Message: "Access denied"}, want: "Authentication failed"},
+		{err: &mysql.MySQLError{Number: 1044, Message: "Access denied for database"}, want: "Authentication failed"},
+		{err: mysql.ErrUnknownPlugin, want: "Authentication failed"},
+		{err: &mysql.MySQLError{Number: 1040, Message: "Too many connections"}, want: "Server refused connection"},
+		{err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, want: "Connection failed"},
+		{err: mysql.ErrInvalidConn, want: "Connection failed"},
+	}
+
+	for _, tt := range tests {
+		if got := mysqlConnectMessage(tt.err); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("mysqlConnectMessage(%v) = %q, want prefix %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCheckMySQLMalformedGreeting(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload []byte
+	}{
+		{name: "empty packet", payload: []byte{0x00, 0x00, 0x00, 0x00}},
+		{name: "protocol version only", payload: []byte{0x01, 0x00, 0x00, 0x00, 0x0a}},
+		{name: "unterminated server version", payload: []byte{0x04, 0x00, 0x00, 0x00, 0x0a, '8', '.', '0'}},
+		{name: "truncated header", payload: []byte{0x20, 0x00}},
+		{name: "length beyond data", payload: []byte{0xff, 0xff, 0xff, 0x00, 0x0a, '8', 0x00}},
+		{name: "short error packet", payload: []byte{0x01, 0x00, 0x00, 0x00, 0xff}},
+	}
+
+	config, _ := json.Marshal(models.DatabaseConfig{Username: "root", Password: "secret", SSLMode: "disable"})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ln.Close()
+
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				conn.Write(tt.payload)
+				conn.Close()
+			}()
+
+			mon := models.Monitor{ID: 1, Target: ln.Addr().String(), Config: config, Timeout: 2 * time.Second}
+			res := checkMySQL(context.Background(), mon)
+
+			if res.Status != models.StatusDown {
+				t.Fatalf("status = %s (%s), want down", res.Status, res.Message)
+			}
+		})
+	}
+}
+
+func TestCheckMySQLAllowRetriesWithTLS(t *testing.T) {
+	// Error packet 3159: connections using insecure transport are prohibited.
+	msg := "#HY000Connections using insecure transport are prohibited"
+	payload := append([]byte{0xff, 0x57, 0x0c}, msg...)
+	packet := append([]byte{byte(len(payload)), 0x00, 0x00, 0x00}, payload...)
+
+	tests := []struct {
+		mode      string
+		wantDials int32
+	}{
+		{mode: "disable", wantDials: 1},
+		{mode: "allow", wantDials: 2},
+	}
+
+	for _, tt := range tests {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var dials atomic.Int32
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				dials.Add(1)
+				conn.Write(packet)
+				conn.Close()
+			}
+		}()
+
+		config, _ := json.Marshal(models.DatabaseConfig{Username: "root", SSLMode: tt.mode})
+		res := checkMySQL(context.Background(), models.Monitor{ID: 1, Target: ln.Addr().String(), Config: config, Timeout: 2 * time.Second})
+		ln.Close()
+
+		if res.Status != models.StatusDown || !strings.HasPrefix(res.Message, "Server refused connection") {
+			t.Fatalf("%s: got %s %q", tt.mode, res.Status, res.Message)
+		}
+		if got := dials.Load(); got != tt.wantDials {
+			t.Fatalf("%s: %d connections, want %d", tt.mode, got, tt.wantDials)
+		}
+	}
+}
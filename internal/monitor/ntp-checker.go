@@ -0,0 +1,201 @@
+package monitor
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+const (
+	DefaultNTPDegradedOffset = 100.0
+	DefaultNTPDownOffset     = 1000.0
+
+	ntpPacketSize = 48
+	// Seconds between the NTP epoch (1900) and the Unix epoch.
+	ntpEpochOffset = 2208988800
+)
+
+func checkNTP(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.NTPConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] NTP configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	degradedOffset := config.DegradedOffset
+	if degradedOffset <= 0 {
+		degradedOffset = DefaultNTPDegradedOffset
+	}
+
+	downOffset := config.DownOffset
+	if downOffset <= 0 {
+		downOffset = DefaultNTPDownOffset
+	}
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, "123")
+	}
+
+	dialer := net.Dialer{Timeout: m.Timeout}
+	conn, err := dialer.DialContext(ctx, "udp", target)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Could not open UDP socket: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer conn.Close()
+	defer closeOnCancel(ctx, conn)()
+
+	_ = conn.SetDeadline(time.Now().Add(m.Timeout))
+
+	// LI = 0, version 4, mode 3 (client).
+	request := make([]byte, ntpPacketSize)
+	request[0] = 0x23
+
+	sentAt := time.Now()
+	transmit := toNTPTime(sentAt)
+	binary.BigEndian.PutUint64(request[40:], transmit)
+
+	if _, err := conn.Write(request); err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Failed to send NTP request: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	receivedAt := time.Now()
+
+	if err != nil {
+		message := fmt.Sprintf("No NTP response: %s", err.Error())
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			message = "Timeout: no NTP response received"
+		}
+
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   message,
+			CheckedAt: time.Now(),
+		}
+	}
+
+	fail := func(format string, args ...any) models.CheckResult {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   receivedAt.Sub(sentAt).Milliseconds(),
+			Message:   fmt.Sprintf(format, args...),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	if n < ntpPacketSize {
+		return fail("Short NTP response (%d bytes)", n)
+	}
+
+	if mode := response[0] & 0x07; mode != 4 {
+		return fail("Unexpected NTP mode %d", mode)
+	}
+
+	// The server echoes our transmit time; anything else is a stray packet.
+	if binary.BigEndian.Uint64(response[24:]) != transmit {
+		return fail("NTP response does not match the request")
+	}
+
+	details := models.NTPDetails{
+		LeapIndicator:  int(response[0] >> 6),
+		Stratum:        int(response[1]),
+		RootDelay:      ntpShortMillis(binary.BigEndian.Uint32(response[4:])),
+		RootDispersion: ntpShortMillis(binary.BigEndian.Uint32(response[8:])),
+		RefID:          ntpRefID(response[1], response[12:16]),
+	}
+
+	if details.Stratum == 0 {
+		return fail("Kiss-o'-Death from server: %s", details.RefID)
+	}
+
+	serverReceive := fromNTPTime(binary.BigEndian.Uint64(response[32:]))
+	serverTransmit := fromNTPTime(binary.BigEndian.Uint64(response[40:]))
+
+	offset := (serverReceive.Sub(sentAt) + serverTransmit.Sub(receivedAt)) / 2
+	delay := receivedAt.Sub(sentAt) - serverTransmit.Sub(serverReceive)
+
+	details.Offset = roundMillis(float64(offset) / float64(time.Millisecond))
+	details.Delay = roundMillis(float64(delay) / float64(time.Millisecond))
+
+	status := models.StatusUp
+	message := fmt.Sprintf("Offset %.3fms, stratum %d", details.Offset, details.Stratum)
+
+	absOffset := math.Abs(details.Offset)
+
+	switch {
+	case details.LeapIndicator == 3 || details.Stratum >= 16:
+		status = models.StatusDown
+		message = fmt.Sprintf("Server clock is not synchronized (stratum %d)", details.Stratum)
+	case absOffset > downOffset:
+		status = models.StatusDown
+		message = fmt.Sprintf("Clock offset %.3fms (Limit: %gms)", details.Offset, downOffset)
+	case absOffset > degradedOffset:
+		status = models.StatusDegraded
+		message = fmt.Sprintf("Clock offset %.3fms (Limit: %gms)", details.Offset, degradedOffset)
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     delay.Milliseconds(),
+		ResultValue: strconv.FormatFloat(details.Offset, 'f', 3, 64),
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+func toNTPTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := (uint64(t.Nanosecond()) << 32) / 1e9
+	return seconds<<32 | fraction
+}
+
+// fromNTPTime converts a 64-bit timestamp. Seconds with the top bit clear are
+// read as era 1 (after February 2036).
+func fromNTPTime(ts uint64) time.Time {
+	seconds := int64(ts >> 32)
+	if seconds < 0x80000000 {
+		seconds += 1 << 32
+	}
+
+	nanos := int64(((ts & 0xffffffff) * 1e9) >> 32)
+	return time.Unix(seconds-ntpEpochOffset, nanos)
+}
+
+// ntpShortMillis converts a 16.16 fixed point number of seconds.
+func ntpShortMillis(v uint32) float64 {
+	return math.Round(float64(v)/65536*1e6) / 1e3
+}
+
+// ntpRefID is a four letter code for stratum 0 (kiss codes) and 1 (reference
+// clocks), and the upstream server's IPv4 address otherwise.
+func ntpRefID(stratum byte, id []byte) string {
+	if stratum <= 1 {
+		return strings.TrimRight(string(id), "\x00 ")
+	}
+	return net.IP(id).String()
+}
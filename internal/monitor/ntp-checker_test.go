@@ -0,0 +1,151 @@
+package monitor
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestNTPTimeRoundTrip(t *testing.T) {
+	times := []time.Time{
+		time.Date(2026, 10, 18, 12, 30, 15, 123456789, time.UTC),
+		time.Date(2036, 2, 7, 6, 28, 15, 0, time.UTC),
+		time.Date(2040, 1, 1, 0, 0, 0, 500000000, time.UTC),
+	}
+
+	for _, want := range times {
+		got := fromNTPTime(toNTPTime(want))
+		if diff := got.Sub(want); diff < -time.Nanosecond || diff > time.Nanosecond {
+			t.Errorf("round trip of %s = %s", want, got)
+		}
+	}
+}
+
+func TestFromNTPTimeEra(t *testing.T) {
+	tests := []struct {
+		seconds uint64
+		want    time.Time
+	}{
+		{seconds: ntpEpochOffset, want: time.Unix(0, 0)},
+		{seconds: 0xffffffff, want: time.Date(2036, 2, 7, 6, 28, 15, 0, time.UTC)},
+		{seconds: 0, want: time.Date(2036, 2, 7, 6, 28, 16, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := fromNTPTime(tt.seconds << 32); !got.Equal(tt.want) {
+			t.Errorf("fromNTPTime(%d) = %s, want %s", tt.seconds, got.UTC(), tt.want)
+		}
+	}
+}
+
+func TestNTPShortMillis(t *testing.T) {
+	tests := []struct {
+		v    uint32
+		want float64
+	}{
+		{v: 0, want: 0},
+		{v: 1 << 16, want: 1000},
+		{v: 1 << 15, want: 500},
+		{v: 0x00000148, want: 5.005},
+	}
+
+	for _, tt := range tests {
+		if got := ntpShortMillis(tt.v); got != tt.want {
+			t.Errorf("ntpShortMillis(%#x) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestNTPRefID(t *testing.T) {
+	tests := []struct {
+		stratum byte
+		id      []byte
+		want    string
+	}{
+		{stratum: 0, id: []byte("RATE"), want: "RATE"},
+		{stratum: 1, id: []byte("GPS\x00"), want: "GPS"},
+		{stratum: 2, id: []byte{192, 0, 2, 1}, want: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		if got := ntpRefID(tt.stratum, tt.id); got != tt.want {
+			t.Errorf("ntpRefID(%d, %v) = %q, want %q", tt.stratum, tt.id, got, tt.want)
+		}
+	}
+}
+
+// startNTPServer answers every request with a clock skewed by skew. reply can
+// adjust the packet before it is sent.
+func startNTPServer(t *testing.T, skew time.Duration, reply func([]byte)) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, ntpPacketSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < ntpPacketSize {
+				continue
+			}
+
+			now := toNTPTime(time.Now().Add(skew))
+
+			resp := make([]byte, ntpPacketSize)
+			resp[0] = 0x24 // LI 0, version 4, mode 4 (server)
+			resp[1] = 2
+			copy(resp[12:16], []byte{192, 0, 2, 1})
+			copy(resp[24:32], buf[40:48])
+			binary.BigEndian.PutUint64(resp[32:], now)
+			binary.BigEndian.PutUint64(resp[40:], now)
+
+			if reply != nil {
+				reply(resp)
+			}
+			conn.WriteTo(resp, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestCheckNTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		skew        time.Duration
+		reply       func([]byte)
+		wantStatus  models.MonitorStatus
+		wantMessage string
+	}{
+		{name: "in sync", wantStatus: models.StatusUp, wantMessage: "stratum 2"},
+		{name: "drifting", skew: 500 * time.Millisecond, wantStatus: models.StatusDegraded, wantMessage: "Limit: 100ms"},
+		{name: "far behind", skew: -5 * time.Second, wantStatus: models.StatusDown, wantMessage: "Limit: 1000ms"},
+		{name: "kiss of death", reply: func(p []byte) { p[1] = 0; copy(p[12:16], "RATE") }, wantStatus: models.StatusDown, wantMessage: "Kiss-o'-Death from server: RATE"},
+		{name: "unsynchronized", reply: func(p []byte) { p[0] = 0xe4 }, wantStatus: models.StatusDown, wantMessage: "not synchronized"},
+		{name: "client mode reply", reply: func(p []byte) { p[0] = 0x23 }, wantStatus: models.StatusDown, wantMessage: "Unexpected NTP mode 3"},
+		{name: "stray packet", reply: func(p []byte) { p[24] ^= 0xff }, wantStatus: models.StatusDown, wantMessage: "does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startNTPServer(t, tt.skew, tt.reply)
+			res := checkNTP(context.Background(), models.Monitor{ID: 1, Target: addr, Timeout: 2 * time.Second})
+
+			if res.Status != tt.wantStatus || !strings.Contains(res.Message, tt.wantMessage) {
+				t.Fatalf("got %s %q, want %s containing %q", res.Status, res.Message, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const DefaultQuery = "SELECT 1"
+
+func checkPostgres(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.DatabaseConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] PostgreSQL configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, "5432")
+	}
+
+	sslMode := config.SSLMode
+	if sslMode == "" {
+		sslMode = "prefer"
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(config.Username, config.Password),
+		Host:     target,
+		Path:     "/" + config.Database,
+		RawQuery: url.Values{"sslmode": {sslMode}}.Encode(),
+	}
+
+	connConfig, err := pgx.ParseConfig(dsn.String())
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid connection settings: %s", err.Error()), CheckedAt: time.Now()}
+	}
+	connConfig.ConnectTimeout = m.Timeout
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	conn, err := pgx.ConnectConfig(ctx, connConfig)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf("Connection failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer conn.Close(context.Background())
+
+	details := models.DatabaseDetails{
+		ServerVersion: conn.PgConn().ParameterStatus("server_version"),
+		ConnectTime:   time.Since(start).Milliseconds(),
+	}
+
+	query := config.Query
+	if query == "" {
+		query = DefaultQuery
+	}
+
+	queryStart := time.Now()
+	value, err := queryFirstValue(ctx, conn, query)
+	details.QueryTime = time.Since(queryStart).Milliseconds()
+
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   details.QueryTime,
+			Message:   fmt.Sprintf("Query failed: %s", err.Error()),
+			Details:   marshalDetails(details),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	return databaseResult(m, config, value, details)
+}
+
+// queryFirstValue runs query and returns the first column of the first row,
+// or "" when the query returns no rows.
+func queryFirstValue(ctx context.Context, conn *pgx.Conn, query string) (string, error) {
+	rows, err := conn.Query(ctx, query, pgx.QueryExecModeSimpleProtocol)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var value string
+	if rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return "", err
+		}
+
+		if len(values) > 0 {
+			if values[0] == nil {
+				value = "NULL"
+			} else {
+				value = fmt.Sprint(values[0])
+			}
+		}
+	}
+
+	return value, rows.Err()
+}
+
+// databaseResult builds the result shared by the SQL checkers once the query
+// has succeeded.
+func databaseResult(m models.Monitor, config models.DatabaseConfig, value string, details models.DatabaseDetails) models.CheckResult {
+	status := models.StatusUp
+	message := fmt.Sprintf("Query returned '%s'", value)
+
+	if config.ExpectedValue != "" && value != config.ExpectedValue {
+		status = models.StatusDown
+		message = fmt.Sprintf("Unexpected query result. Expected: '%s', Found: '%s'", config.ExpectedValue, value)
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     details.QueryTime,
+		ResultValue: value,
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
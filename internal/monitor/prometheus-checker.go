@@ -0,0 +1,348 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+const DefaultPrometheusOperator = ">"
+
+type promSample struct {
+	name   string
+	series string
+	labels map[string]string
+	value  float64
+}
+
+func checkPrometheus(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.PrometheusConfig
+	if err := json.Unmarshal(m.Config, &config); err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] Prometheus configuration error.", CheckedAt: time.Now()}
+	}
+
+	operator := config.Operator
+	if operator == "" {
+		operator = DefaultPrometheusOperator
+	}
+
+	req, err := buildHTTPRequest(m.Target, models.HTTPConfig{Headers: config.Headers, Auth: config.Auth})
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid HTTP request configuration: %s", err.Error()), CheckedAt: time.Now()}
+	}
+	req = req.WithContext(ctx)
+
+	// Ask for the text format; some exporters default to protobuf.
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "text/plain;version=0.0.4")
+	}
+
+	client := http.Client{Timeout: m.Timeout}
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf("Scrape failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize+1))
+	latency := time.Since(start).Milliseconds()
+
+	fail := func(format string, args ...any) models.CheckResult {
+		return models.CheckResult{
+			MonitorID:  m.ID,
+			Status:     models.StatusDown,
+			Latency:    latency,
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf(format, args...),
+			CheckedAt:  time.Now(),
+		}
+	}
+
+	if err != nil {
+		return fail("Failed to read metrics: %s", err.Error())
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fail("Unexpected status %s", resp.Status)
+	}
+
+	// A truncated exposition could silently drop the wanted series.
+	if len(body) > MaxBodySize {
+		return fail("Metrics output exceeds %d MiB", MaxBodySize>>20)
+	}
+
+	samples, err := findSamples(body, config.Metric, config.Labels)
+	if err != nil {
+		return fail("Invalid metrics output: %s", err.Error())
+	}
+
+	if len(samples) == 0 {
+		return fail("No series matches %s", formatSeries(config.Metric, config.Labels))
+	}
+
+	value, err := aggregateSamples(samples, config.Aggregate)
+	if err != nil {
+		return fail("%s", err.Error())
+	}
+
+	details := models.PrometheusDetails{
+		Value:   formatMetricValue(value),
+		Matched: len(samples),
+	}
+	for _, sample := range samples {
+		details.Series = append(details.Series, sample.series)
+	}
+
+	series := samples[0].series
+	if len(samples) > 1 {
+		series = fmt.Sprintf("%s(%s)", config.Aggregate, formatSeries(config.Metric, config.Labels))
+	}
+
+	status := models.StatusUp
+	message := fmt.Sprintf("%s = %s", series, details.Value)
+
+	if config.DownThreshold != nil && compareMetric(value, operator, *config.DownThreshold) {
+		status = models.StatusDown
+		message = fmt.Sprintf("%s = %s (Limit: %s %s)", series, details.Value, operator, formatMetricValue(*config.DownThreshold))
+	} else if config.DegradedThreshold != nil && compareMetric(value, operator, *config.DegradedThreshold) {
+		status = models.StatusDegraded
+		message = fmt.Sprintf("%s = %s (Limit: %s %s)", series, details.Value, operator, formatMetricValue(*config.DegradedThreshold))
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     latency,
+		StatusCode:  resp.StatusCode,
+		ResultValue: details.Value,
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// findSamples returns the samples of metric whose labels include every
+// wanted label.
+func findSamples(body []byte, metric string, wanted map[string]string) ([]promSample, error) {
+	var samples []promSample
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxBodySize)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || !strings.HasPrefix(line, metric) {
+			continue
+		}
+
+		sample, err := parseSample(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if sample.name == metric && labelsMatch(sample.labels, wanted) {
+			samples = append(samples, sample)
+		}
+	}
+
+	return samples, scanner.Err()
+}
+
+// parseSample parses one exposition line: name{label="value",...} value [timestamp].
+func parseSample(line string) (promSample, error) {
+	sample := promSample{labels: map[string]string{}}
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return sample, fmt.Errorf("malformed line '%s'", truncate(line, 80))
+	}
+
+	name := line[:end]
+	rest := line[end:]
+
+	if rest[0] == '{' {
+		closing, err := parseLabels(rest[1:], sample.labels)
+		if err != nil {
+			return sample, fmt.Errorf("%s in '%s'", err.Error(), truncate(line, 80))
+		}
+		rest = rest[1+closing+1:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return sample, fmt.Errorf("missing value in '%s'", truncate(line, 80))
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("invalid value '%s'", fields[0])
+	}
+
+	sample.name = name
+	sample.series = formatSeries(name, sample.labels)
+	sample.value = value
+	return sample, nil
+}
+
+// parseLabels reads label pairs up to the closing brace and returns its
+// offset in s.
+func parseLabels(s string, labels map[string]string) (int, error) {
+	i := 0
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == ',') {
+			i++
+		}
+		if i >= len(s) {
+			return 0, fmt.Errorf("unclosed label set")
+		}
+		if s[i] == '}' {
+			return i, nil
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 {
+			return 0, fmt.Errorf("malformed label")
+		}
+		name := strings.TrimSpace(s[i : i+eq])
+		i += eq + 1
+
+		if i >= len(s) || s[i] != '"' {
+			return 0, fmt.Errorf("unquoted label value")
+		}
+		i++
+
+		var value strings.Builder
+		for {
+			if i >= len(s) {
+				return 0, fmt.Errorf("unterminated label value")
+			}
+
+			c := s[i]
+			i++
+
+			if c == '"' {
+				break
+			}
+
+			if c == '\\' && i < len(s) {
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				i++
+				continue
+			}
+
+			value.WriteByte(c)
+		}
+
+		labels[name] = value.String()
+	}
+}
+
+func labelsMatch(labels, wanted map[string]string) bool {
+	for name, value := range wanted {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func aggregateSamples(samples []promSample, aggregate string) (float64, error) {
+	if len(samples) == 1 {
+		return samples[0].value, nil
+	}
+
+	switch aggregate {
+	case "sum", "avg":
+		var total float64
+		for _, s := range samples {
+			total += s.value
+		}
+		if aggregate == "avg" {
+			return total / float64(len(samples)), nil
+		}
+		return total, nil
+
+	case "min", "max":
+		result := samples[0].value
+		for _, s := range samples[1:] {
+			if aggregate == "min" {
+				result = math.Min(result, s.value)
+			} else {
+				result = math.Max(result, s.value)
+			}
+		}
+		return result, nil
+
+	default:
+		return 0, fmt.Errorf("%d series match; add labels or set an aggregate (sum, min, max, avg)", len(samples))
+	}
+}
+
+func compareMetric(value float64, operator string, threshold float64) bool {
+	switch operator {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "==":
+		return value == threshold
+	case "!=":
+		return value != threshold
+	default:
+		return false
+	}
+}
+
+func formatMetricValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatSeries renders a series the way Prometheus does, with labels sorted
+// by name.
+func formatSeries(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+
+	names := make([]string, 0, len(labels))
+	for label := range labels {
+		names = append(names, label)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, label := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", label, labels[label]))
+	}
+
+	return fmt.Sprintf("%s{%s}", name, strings.Join(pairs, ","))
+}
@@ -0,0 +1,140 @@
+package monitor
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseSample(t *testing.T) {
+	tests := []struct {
+		line       string
+		wantName   string
+		wantLabels map[string]string
+		wantValue  float64
+		wantErr    string
+	}{
+		{line: "up 1", wantName: "up", wantLabels: map[string]string{}, wantValue: 1},
+		{line: "go_goroutines\t42 1700000000000", wantName: "go_goroutines", wantLabels: map[string]string{}, wantValue: 42},
+		{line: `http_requests_total{method="get",code="200"} 1027`, wantName: "http_requests_total", wantLabels: map[string]string{"method": "get", "code": "200"}, wantValue: 1027},
+		{line: `queue{name="a \"b\"\\c\nd", } 3.5e2`, wantName: "queue", wantLabels: map[string]string{"name": "a \"b\"\\c\nd"}, wantValue: 350},
+		{line: `latency_bucket{le="+Inf"} +Inf`, wantName: "latency_bucket", wantLabels: map[string]string{"le": "+Inf"}, wantValue: math.Inf(1)},
+		{line: "{job=\"x\"} 1", wantErr: "malformed line"},
+		{line: "up", wantErr: "malformed line"},
+		{line: `up{job="x"}`, wantErr: "missing value"},
+		{line: `up{job="x"`, wantErr: "unclosed label set"},
+		{line: `up{job=x} 1`, wantErr: "unquoted label value"},
+		{line: `up{job} 1`, wantErr: "malformed label"},
+		{line: `up{job="x} 1`, wantErr: "unterminated label value"},
+		{line: "up one", wantErr: "invalid value 'one'"},
+	}
+
+	for _, tt := range tests {
+		sample, err := parseSample(tt.line)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseSample(%q) error = %v, want %q", tt.line, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSample(%q) error = %v", tt.line, err)
+			continue
+		}
+
+		if sample.name != tt.wantName || sample.value != tt.wantValue || !reflect.DeepEqual(sample.labels, tt.wantLabels) {
+			t.Errorf("parseSample(%q) = %s %v %v", tt.line, sample.name, sample.labels, sample.value)
+		}
+	}
+}
+
+func TestFindSamples(t *testing.T) {
+	body := []byte(`# HELP http_requests_total Requests served.
+# TYPE http_requests_total counter
+http_requests_total{method="get",code="200"} 10
+http_requests_total{method="get",code="500"} 2
+http_requests_total{method="post",code="200"} 5
+http_requests_total_created{method="get"} 1700000000
+http_requests 99
+other_metric 1
+`)
+
+	tests := []struct {
+		wanted map[string]string
+		want   []string
+	}{
+		{want: []string{`http_requests_total{code="200",method="get"}`, `http_requests_total{code="500",method="get"}`, `http_requests_total{code="200",method="post"}`}},
+		{wanted: map[string]string{"method": "get"}, want: []string{`http_requests_total{code="200",method="get"}`, `http_requests_total{code="500",method="get"}`}},
+		{wanted: map[string]string{"code": "404"}},
+	}
+
+	for _, tt := range tests {
+		samples, err := findSamples(body, "http_requests_total", tt.wanted)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, s := range samples {
+			got = append(got, s.series)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("findSamples(%v) = %v, want %v", tt.wanted, got, tt.want)
+		}
+	}
+
+	if _, err := findSamples([]byte("up{job=\"x\" 1\n"), "up", nil); err == nil {
+		t.Error("findSamples accepted a malformed line of the wanted metric")
+	}
+}
+
+func TestAggregateSamples(t *testing.T) {
+	samples := []promSample{{value: 4}, {value: 1}, {value: 7}}
+
+	tests := []struct {
+		aggregate string
+		want      float64
+		wantErr   bool
+	}{
+		{aggregate: "sum", want: 12},
+		{aggregate: "avg", want: 4},
+		{aggregate: "min", want: 1},
+		{aggregate: "max", want: 7},
+		{aggregate: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := aggregateSamples(samples, tt.aggregate)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("aggregateSamples(%q) = %v, %v", tt.aggregate, got, err)
+		}
+	}
+
+	if got, err := aggregateSamples(samples[:1], ""); err != nil || got != 4 {
+		t.Errorf("a single series needs no aggregate: %v, %v", got, err)
+	}
+}
+
+func TestCompareMetric(t *testing.T) {
+	tests := []struct {
+		value    float64
+		operator string
+		want     bool
+	}{
+		{value: 5, operator: ">", want: true},
+		{value: 3, operator: ">", want: false},
+		{value: 3, operator: ">=", want: true},
+		{value: 2, operator: "<", want: true},
+		{value: 3, operator: "<=", want: true},
+		{value: 3, operator: "==", want: true},
+		{value: 4, operator: "!=", want: true},
+		{value: 3, operator: "=~", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := compareMetric(tt.value, tt.operator, 3); got != tt.want {
+			t.Errorf("compareMetric(%v %s 3) = %v, want %v", tt.value, tt.operator, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+func checkRedis(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.RedisConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] Redis configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, "6379")
+	}
+
+	opts := &redis.Options{
+		Addr:            target,
+		Username:        config.Username,
+		Password:        config.Password,
+		DB:              config.DB,
+		DialTimeout:     m.Timeout,
+		ReadTimeout:     m.Timeout,
+		WriteTimeout:    m.Timeout,
+		MaxRetries:      -1,
+		PoolSize:        1,
+		DisableIdentity: true,
+	}
+
+	if config.TLS {
+		host, _, _ := net.SplitHostPort(target)
+		opts.TLSConfig = &tls.Config{ServerName: host}
+	}
+
+	client := redis.NewClient(opts)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// The first command dials and authenticates, so its duration is the
+	// connection time.
+	pong, err := client.Ping(ctx).Result()
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf("PING failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	details := models.DatabaseDetails{ConnectTime: time.Since(start).Milliseconds()}
+
+	queryStart := time.Now()
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(queryStart).Milliseconds(),
+			Message:   fmt.Sprintf("PING failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	details.QueryTime = time.Since(queryStart).Milliseconds()
+
+	// INFO only accepts several sections from Redis 7 on, so each section
+	// is read separately.
+	if server, err := client.Info(ctx, "server").Result(); err == nil {
+		details.ServerVersion = parseRedisInfo(server)["redis_version"]
+	}
+
+	replication, err := client.Info(ctx, "replication").Result()
+	if err == nil {
+		details.Role = parseRedisInfo(replication)["role"]
+	}
+
+	status := models.StatusUp
+	message := fmt.Sprintf("PING returned %s", pong)
+	value := pong
+
+	if config.ExpectedRole != "" {
+		expected := normalizeRedisRole(config.ExpectedRole)
+		value = details.Role
+
+		if err != nil {
+			status = models.StatusDown
+			message = fmt.Sprintf("Could not read replication role: %s", err.Error())
+		} else if details.Role != expected {
+			status = models.StatusDown
+			message = fmt.Sprintf("Unexpected replication role. Expected: '%s', Found: '%s'", expected, details.Role)
+		} else {
+			message = fmt.Sprintf("PING returned %s, role is %s", pong, details.Role)
+		}
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     details.QueryTime,
+		ResultValue: value,
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// normalizeRedisRole maps the role names users write to the ones INFO
+// reports.
+func normalizeRedisRole(role string) string {
+	role = strings.ToLower(role)
+	switch role {
+	case "primary":
+		return "master"
+	case "replica":
+		return "slave"
+	}
+	return role
+}
+
+func parseRedisInfo(info string) map[string]string {
+	fields := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			fields[key] = value
+		}
+	}
+
+	return fields
+}
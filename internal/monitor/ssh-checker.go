@@ -0,0 +1,177 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"golang.org/x/crypto/ssh"
+)
+
+// RFC 4253 lets servers send other lines before the version banner.
+const maxSSHPreBanner = 8 * 1024
+
+// errHostKeyCaptured stops the handshake once the host key is verified;
+// the check never authenticates.
+var errHostKeyCaptured = errors.New("host key captured")
+
+func checkSSH(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.SSHConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] SSH configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, "22")
+	}
+
+	start := time.Now()
+
+	dialer := net.Dialer{Timeout: m.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Connection failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer conn.Close()
+	defer closeOnCancel(ctx, conn)()
+
+	_ = conn.SetDeadline(time.Now().Add(m.Timeout))
+
+	recorder := &bannerConn{Conn: conn}
+
+	var hostKey ssh.PublicKey
+
+	clientConfig := &ssh.ClientConfig{
+		User:          "pingly",
+		ClientVersion: "SSH-2.0-Pingly",
+		Timeout:       m.Timeout,
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return errHostKeyCaptured
+		},
+	}
+	if config.HostKeyAlgorithm != "" {
+		clientConfig.HostKeyAlgorithms = hostKeyAlgorithms(config.HostKeyAlgorithm)
+	}
+
+	_, _, _, err = ssh.NewClientConn(recorder, target, clientConfig)
+
+	details := models.SSHDetails{
+		Banner:        recorder.banner,
+		HandshakeTime: time.Since(start).Milliseconds(),
+	}
+
+	if hostKey == nil {
+		message := fmt.Sprintf("SSH handshake failed: %s", err.Error())
+		if details.Banner == "" {
+			message = fmt.Sprintf("No SSH banner received: %s", err.Error())
+		}
+
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   details.HandshakeTime,
+			Message:   message,
+			Details:   marshalDetails(details),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	details.KeyType = hostKey.Type()
+	details.Fingerprint = ssh.FingerprintSHA256(hostKey)
+
+	status := models.StatusUp
+	message := fmt.Sprintf("%s host key %s", details.KeyType, details.Fingerprint)
+
+	if expected := normalizeFingerprint(config.Fingerprint); expected != "" && expected != details.Fingerprint {
+		status = models.StatusDown
+		message = fmt.Sprintf("HOST KEY CHANGED. Expected '%s', Found '%s' (%s)", expected, details.Fingerprint, details.KeyType)
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     details.HandshakeTime,
+		ResultValue: details.Fingerprint,
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// hostKeyAlgorithms lists the signature algorithms for a pinned key type. An
+// RSA key can sign with any of three, and servers increasingly refuse the
+// SHA-1 one its type is named after.
+func hostKeyAlgorithms(keyType string) []string {
+	switch keyType {
+	case ssh.KeyAlgoRSA, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSASHA512:
+		return []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+	default:
+		return []string{keyType}
+	}
+}
+
+// normalizeFingerprint accepts fingerprints with or without the "SHA256:"
+// prefix that ssh-keygen -l prints.
+func normalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.TrimSpace(fingerprint)
+	if fingerprint == "" || strings.HasPrefix(fingerprint, "SHA256:") {
+		return fingerprint
+	}
+	return "SHA256:" + strings.TrimRight(fingerprint, "=")
+}
+
+// bannerConn records the server identification line as the SSH client reads
+// it, since the handshake is aborted before the client exposes it.
+type bannerConn struct {
+	net.Conn
+	buf    []byte
+	banner string
+	done   bool
+}
+
+func (c *bannerConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if c.done || n == 0 {
+		return n, err
+	}
+
+	c.buf = append(c.buf, p[:n]...)
+
+	for {
+		i := bytes.IndexByte(c.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := strings.TrimRight(string(c.buf[:i]), "\r")
+		c.buf = c.buf[i+1:]
+
+		if strings.HasPrefix(line, "SSH-") {
+			c.banner = line
+			c.done = true
+			break
+		}
+	}
+
+	if c.done || len(c.buf) > maxSSHPreBanner {
+		c.done = true
+		c.buf = nil
+	}
+
+	return n, err
+}
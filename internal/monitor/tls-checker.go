@@ -0,0 +1,217 @@
+package monitor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+var defaultTLSPorts = map[string]string{
+	"":     "443",
+	"smtp": "25",
+	"imap": "143",
+	"pop3": "110",
+}
+
+func checkTLS(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.TLSConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] TLS configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	config.StartTLS = strings.ToLower(config.StartTLS)
+
+	defaultPort, ok := defaultTLSPorts[config.StartTLS]
+	if !ok {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Unsupported STARTTLS protocol '%s'", config.StartTLS),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	target := m.Target
+	if _, _, err := net.SplitHostPort(target); err != nil {
+		target = net.JoinHostPort(target, defaultPort)
+	}
+
+	host, _, _ := net.SplitHostPort(target)
+
+	serverName := config.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+
+	start := time.Now()
+
+	dialer := net.Dialer{Timeout: m.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Connection failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer conn.Close()
+	defer closeOnCancel(ctx, conn)()
+
+	_ = conn.SetDeadline(time.Now().Add(m.Timeout))
+
+	if config.StartTLS != "" {
+		if err := startTLS(conn, config.StartTLS); err != nil {
+			return models.CheckResult{
+				MonitorID: m.ID,
+				Status:    models.StatusDown,
+				Latency:   time.Since(start).Milliseconds(),
+				Message:   fmt.Sprintf("STARTTLS negotiation failed: %s", err.Error()),
+				CheckedAt: time.Now(),
+			}
+		}
+	}
+
+	// Verification is done by hand after the handshake so certificate
+	// details can still be reported when the chain is broken.
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true,
+	})
+
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf("TLS handshake failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	latency := time.Since(start).Milliseconds()
+	state := tlsConn.ConnectionState()
+
+	if len(state.PeerCertificates) == 0 {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   latency,
+			Message:   "Server presented no certificate",
+			CheckedAt: time.Now(),
+		}
+	}
+
+	cert := state.PeerCertificates[0]
+	details := describeCertificate(cert, state.Version)
+
+	if err := verifyChain(state.PeerCertificates, serverName); err != nil {
+		details.ChainError = err.Error()
+	}
+
+	status := models.StatusUp
+	message := fmt.Sprintf("Certificate valid for %d days", details.DaysRemaining)
+
+	expiresIn := time.Until(cert.NotAfter)
+
+	if details.ChainError != "" {
+		status = models.StatusDown
+		message = fmt.Sprintf("Certificate validation failed: %s", details.ChainError)
+	} else if expiresIn < 0 {
+		status = models.StatusDown
+		message = fmt.Sprintf("CRITICAL: SSL certificate expired %s (%d days ago)", cert.NotAfter.Format("02/01/2006"), -details.DaysRemaining)
+	} else if details.DaysRemaining <= 30 {
+		status = models.StatusDegraded
+		message = fmt.Sprintf("SSL expires in %d days (%s)", details.DaysRemaining, cert.NotAfter.Format("02/01/2006"))
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     latency,
+		Message:     message,
+		ResultValue: strconv.Itoa(details.DaysRemaining),
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+func describeCertificate(cert *x509.Certificate, version uint16) models.TLSDetails {
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return models.TLSDetails{
+		Subject:       cert.Subject.String(),
+		Issuer:        cert.Issuer.String(),
+		SANs:          sans,
+		KeyType:       keyType(cert),
+		TLSVersion:    tls.VersionName(version),
+		NotAfter:      cert.NotAfter,
+		DaysRemaining: int(time.Until(cert.NotAfter).Hours() / 24),
+	}
+}
+
+func keyType(cert *x509.Certificate) string {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return fmt.Sprintf("ECDSA-%s", key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return "Ed25519"
+	default:
+		return cert.PublicKeyAlgorithm.String()
+	}
+}
+
+func verifyChain(certs []*x509.Certificate, serverName string) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// startTLS upgrades a plain mail protocol connection so the TLS handshake
+// can be performed on it.
+func startTLS(conn net.Conn, protocol string) error {
+	client := newMailClient(conn, protocol)
+
+	if _, err := client.greeting(); err != nil {
+		return fmt.Errorf("greeting: %w", err)
+	}
+
+	// SMTP only accepts STARTTLS after EHLO.
+	if protocol == "smtp" {
+		capabilities, err := client.capabilities()
+		if err != nil {
+			return err
+		}
+
+		if !client.hasStartTLS(capabilities) {
+			return fmt.Errorf("server does not advertise STARTTLS")
+		}
+	}
+
+	return client.startTLS()
+}
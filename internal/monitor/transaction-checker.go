@@ -0,0 +1,308 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptrace"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+func checkTransaction(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.TransactionConfig
+	if err := json.Unmarshal(m.Config, &config); err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] Transaction configuration error.", CheckedAt: time.Now()}
+	}
+
+	if len(config.Steps) == 0 {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "Transaction has no steps", CheckedAt: time.Now()}
+	}
+
+	vars := make(map[string]string, len(config.Variables))
+	for name, value := range config.Variables {
+		vars[name] = value
+	}
+
+	// m.Timeout bounds the whole transaction, not each step.
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	jar, _ := cookiejar.New(nil)
+
+	var details models.TransactionDetails
+	start := time.Now()
+
+	for i, step := range config.Steps {
+		result, err := runTransactionStep(ctx, jar, m.Target, step, vars)
+		details.Steps = append(details.Steps, result)
+
+		if err != nil {
+			failed := i + 1
+			details.FailedStep = &failed
+
+			name := step.Name
+			if name == "" {
+				name = result.URL
+			}
+
+			return models.CheckResult{
+				MonitorID:   m.ID,
+				Status:      models.StatusDown,
+				Latency:     time.Since(start).Milliseconds(),
+				StatusCode:  result.StatusCode,
+				ResultValue: fmt.Sprintf("%d/%d steps", i, len(config.Steps)),
+				Message:     fmt.Sprintf("Step %d (%s) failed: %s", failed, name, err.Error()),
+				Details:     marshalDetails(details),
+				CheckedAt:   time.Now(),
+			}
+		}
+	}
+
+	last := details.Steps[len(details.Steps)-1]
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      models.StatusUp,
+		Latency:     time.Since(start).Milliseconds(),
+		StatusCode:  last.StatusCode,
+		ResultValue: fmt.Sprintf("%d/%d steps", len(config.Steps), len(config.Steps)),
+		Message:     fmt.Sprintf("All %d steps passed", len(config.Steps)),
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+func runTransactionStep(ctx context.Context, jar http.CookieJar, base string, step models.TransactionStep, vars map[string]string) (models.StepResult, error) {
+	var result models.StepResult
+
+	stepURL, err := expandVariables(step.URL, vars)
+	if err != nil {
+		return result, err
+	}
+
+	result.Name = step.Name
+	result.URL, err = resolveStepURL(base, stepURL)
+	if err != nil {
+		return result, err
+	}
+
+	config, err := expandStepConfig(step.HTTPConfig, vars)
+	if err != nil {
+		return result, err
+	}
+
+	acceptedCodes, err := parseStatusRanges(config.AcceptedStatusCodes)
+	if err != nil {
+		return result, fmt.Errorf("invalid accepted status codes: %w", err)
+	}
+
+	followRedirects := config.FollowRedirects == nil || *config.FollowRedirects
+
+	maxRedirects := config.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+
+	client := http.Client{
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if !followRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return nil
+		},
+	}
+
+	req, err := buildHTTPRequest(result.URL, config)
+	if err != nil {
+		return result, err
+	}
+
+	timer := newHTTPTimer()
+	req = req.WithContext(httptrace.WithClientTrace(ctx, timer.trace()))
+
+	start := time.Now()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Duration = time.Since(start).Milliseconds()
+		result.Timing = timer.timing(time.Time{})
+		result.Error = err.Error()
+		return result, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+
+	result.StatusCode = resp.StatusCode
+	result.Duration = time.Since(start).Milliseconds()
+	result.Timing = timer.timing(time.Now())
+
+	fail := func(err error) (models.StepResult, error) {
+		result.Error = err.Error()
+		return result, err
+	}
+
+	if err != nil {
+		return fail(fmt.Errorf("failed to read response body: %w", err))
+	}
+
+	if !statusAccepted(acceptedCodes, resp.StatusCode) {
+		return fail(fmt.Errorf("unexpected status %s", resp.Status))
+	}
+
+	if failure := evaluateAssertions(config.Assertions, body); failure != "" {
+		return fail(fmt.Errorf("%s", failure))
+	}
+
+	for _, extraction := range step.Extract {
+		value, err := extractValue(extraction, resp.Header, body)
+		if err != nil {
+			return fail(fmt.Errorf("extracting '%s': %w", extraction.Name, err))
+		}
+		vars[extraction.Name] = value
+	}
+
+	return result, nil
+}
+
+// resolveStepURL lets steps use paths relative to the monitor target. An
+// empty URL calls the target itself.
+func resolveStepURL(base, ref string) (string, error) {
+	if ref == "" {
+		return base, nil
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid target URL: %w", err)
+	}
+
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid step URL: %w", err)
+	}
+
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+func expandVariables(s string, vars map[string]string) (string, error) {
+	var missing string
+
+	expanded := variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := variablePattern.FindStringSubmatch(match)[1]
+
+		value, ok := vars[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+
+	if missing != "" {
+		return "", fmt.Errorf("undefined variable '%s'", missing)
+	}
+
+	return expanded, nil
+}
+
+// expandStepConfig returns a copy of config with variables substituted in
+// the headers, body, credentials and assertion paths and values.
+func expandStepConfig(config models.HTTPConfig, vars map[string]string) (models.HTTPConfig, error) {
+	var err error
+	expand := func(s string) string {
+		if err != nil {
+			return s
+		}
+
+		var out string
+		out, err = expandVariables(s, vars)
+		return out
+	}
+
+	out := config
+
+	if config.Headers != nil {
+		out.Headers = make(map[string]string, len(config.Headers))
+		for name, value := range config.Headers {
+			out.Headers[name] = expand(value)
+		}
+	}
+
+	out.Body = expand(config.Body)
+
+	if config.Auth != nil {
+		auth := *config.Auth
+		auth.Username = expand(auth.Username)
+		auth.Password = expand(auth.Password)
+		auth.Token = expand(auth.Token)
+		out.Auth = &auth
+	}
+
+	out.Assertions = make([]models.HTTPAssertion, len(config.Assertions))
+	for i, assertion := range config.Assertions {
+		assertion.Path = expand(assertion.Path)
+		assertion.Value = expand(assertion.Value)
+		out.Assertions[i] = assertion
+	}
+
+	return out, err
+}
+
+func extractValue(extraction models.Extraction, header http.Header, body []byte) (string, error) {
+	switch extraction.Source {
+	case models.ExtractJSONPath:
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", fmt.Errorf("response body is not valid JSON")
+		}
+
+		value, err := lookupJSONPath(doc, extraction.Expression)
+		if err != nil {
+			return "", err
+		}
+		return jsonValueString(value), nil
+
+	case models.ExtractHeader:
+		value := header.Get(extraction.Expression)
+		if value == "" {
+			return "", fmt.Errorf("header '%s' not found", extraction.Expression)
+		}
+		return value, nil
+
+	case models.ExtractRegex:
+		re, err := regexp.Compile(extraction.Expression)
+		if err != nil {
+			return "", fmt.Errorf("invalid regex '%s'", extraction.Expression)
+		}
+
+		match := re.FindSubmatch(body)
+		if match == nil {
+			return "", fmt.Errorf("body does not match /%s/", extraction.Expression)
+		}
+
+		// The first capture group wins; without groups the whole match is used.
+		if len(match) > 1 {
+			return string(match[1]), nil
+		}
+		return string(match[0]), nil
+
+	default:
+		return "", fmt.Errorf("unknown extraction source '%s'", strings.TrimSpace(string(extraction.Source)))
+	}
+}
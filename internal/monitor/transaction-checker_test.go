@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestExpandVariables(t *testing.T) {
+	vars := map[string]string{"token": "abc123", "user.id": "42", "empty": ""}
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{in: "no variables", want: "no variables"},
+		{in: "Bearer {{token}}", want: "Bearer abc123"},
+		{in: "/users/{{ user.id }}/orders?t={{token}}", want: "/users/42/orders?t=abc123"},
+		{in: "[{{empty}}]", want: "[]"},
+		{in: "{token} {{ token", want: "{token} {{ token"},
+		{in: "{{missing}} and {{other}}", wantErr: "undefined variable 'missing'"},
+	}
+
+	for _, tt := range tests {
+		got, err := expandVariables(tt.in, vars)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expandVariables(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("expandVariables(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestExpandStepConfigLeavesOriginal(t *testing.T) {
+	config := models.HTTPConfig{
+		Headers:    map[string]string{"Authorization": "Bearer {{token}}"},
+		Body:       `{"id": "{{id}}"}`,
+		Auth:       &models.HTTPAuth{Token: "{{token}}"},
+		Assertions: []models.HTTPAssertion{{Path: "$.items[{{index}}]", Value: "{{id}}"}},
+	}
+	vars := map[string]string{"token": "t", "id": "7", "index": "0"}
+
+	out, err := expandStepConfig(config, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Headers["Authorization"] != "Bearer t" || out.Body != `{"id": "7"}` || out.Auth.Token != "t" ||
+		out.Assertions[0].Path != "$.items[0]" || out.Assertions[0].Value != "7" {
+		t.Fatalf("expanded config = %+v", out)
+	}
+
+	if config.Headers["Authorization"] != "Bearer {{token}}" || config.Auth.Token != "{{token}}" || config.Assertions[0].Value != "{{id}}" {
+		t.Fatalf("original config was modified: %+v", config)
+	}
+
+	if _, err := expandStepConfig(config, map[string]string{"token": "t"}); err == nil {
+		t.Fatal("expandStepConfig accepted an undefined variable")
+	}
+}
+
+func TestExtractValue(t *testing.T) {
+	header := http.Header{"X-Request-Id": {"req-1"}}
+	body := []byte(`{"data": {"token": "abc", "count": 3}}`)
+
+	tests := []struct {
+		extraction models.Extraction
+		want       string
+		wantErr    string
+	}{
+		{extraction: models.Extraction{Source: models.ExtractJSONPath, Expression: "$.data.token"}, want: "abc"},
+		{extraction: models.Extraction{Source: models.ExtractJSONPath, Expression: "$.data.count"}, want: "3"},
+		{extraction: models.Extraction{Source: models.ExtractJSONPath, Expression: "$.data.missing"}, wantErr: "not found"},
+		{extraction: models.Extraction{Source: models.ExtractHeader, Expression: "x-request-id"}, want: "req-1"},
+		{extraction: models.Extraction{Source: models.ExtractHeader, Expression: "X-Missing"}, wantErr: "header 'X-Missing' not found"},
+		{extraction: models.Extraction{Source: models.ExtractRegex, Expression: `"token": "(\w+)"`}, want: "abc"},
+		{extraction: models.Extraction{Source: models.ExtractRegex, Expression: `count": \d`}, want: `count": 3`},
+		{extraction: models.Extraction{Source: models.ExtractRegex, Expression: `nope`}, wantErr: "does not match"},
+		{extraction: models.Extraction{Source: models.ExtractRegex, Expression: `(`}, wantErr: "invalid regex"},
+		{extraction: models.Extraction{Source: "xpath", Expression: "//a"}, wantErr: "unknown extraction source 'xpath'"},
+	}
+
+	for _, tt := range tests {
+		got, err := extractValue(tt.extraction, header, body)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("extractValue(%+v) error = %v, want %q", tt.extraction, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("extractValue(%+v) = %q, %v, want %q", tt.extraction, got, err, tt.want)
+		}
+	}
+
+	if _, err := extractValue(models.Extraction{Source: models.ExtractJSONPath, Expression: "$.a"}, header, []byte("<html>")); err == nil {
+		t.Error("extractValue accepted a non-JSON body")
+	}
+}
@@ -0,0 +1,183 @@
+package monitor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+const MaxUDPResponse = 64 * 1024
+
+func checkUDP(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.UDPConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] UDP configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	payload, err := decodePayload(config.Payload, config.PayloadFormat)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Invalid UDP payload: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	if _, _, err := net.SplitHostPort(m.Target); err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   "UDP target must be in host:port format",
+			CheckedAt: time.Now(),
+		}
+	}
+
+	dialer := net.Dialer{Timeout: m.Timeout}
+	conn, err := dialer.DialContext(ctx, "udp", m.Target)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Could not open UDP socket: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer conn.Close()
+	defer closeOnCancel(ctx, conn)()
+
+	_ = conn.SetDeadline(time.Now().Add(m.Timeout))
+
+	start := time.Now()
+
+	if _, err := conn.Write(payload); err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   fmt.Sprintf("Failed to send payload: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	buf := make([]byte, MaxUDPResponse)
+	n, err := conn.Read(buf)
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		msg := err.Error()
+
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			msg = "Timeout: no UDP response received"
+		} else if strings.Contains(msg, "refused") {
+			msg = "Port Unreachable: nothing is listening on this UDP port"
+		}
+
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Message:   msg,
+			CheckedAt: time.Now(),
+		}
+	}
+
+	response := buf[:n]
+	details := models.UDPDetails{
+		Bytes:       n,
+		ResponseHex: hex.EncodeToString(response),
+		RTT:         latency,
+	}
+
+	resultValue := details.ResponseHex
+	if isPrintableText(response) {
+		details.ResponseText = string(response)
+		resultValue = details.ResponseText
+	}
+
+	resultValue = truncate(resultValue, 255)
+
+	status := models.StatusUp
+	message := fmt.Sprintf("Received %d bytes", n)
+
+	if config.Expect != "" {
+		matched, err := matchResponse(response, config.Expect, config.ExpectFormat)
+		if err != nil {
+			status = models.StatusDown
+			message = fmt.Sprintf("Invalid expected pattern: %s", err.Error())
+		} else if !matched {
+			status = models.StatusDown
+			message = fmt.Sprintf("Unexpected response: does not match '%s'", config.Expect)
+		}
+	}
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      status,
+		Latency:     latency,
+		ResultValue: resultValue,
+		Message:     message,
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// isPrintableText reports whether a response can be stored as text. NUL and
+// other control bytes are rejected by Postgres or mangle the alerts, so such
+// responses are kept as hex only.
+func isPrintableText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+
+	for _, r := range string(b) {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodePayload(payload, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "", "text":
+		return []byte(payload), nil
+	case "hex":
+		return hex.DecodeString(strings.ReplaceAll(payload, " ", ""))
+	default:
+		return nil, fmt.Errorf("unknown payload format '%s'", format)
+	}
+}
+
+// matchResponse checks the response against a regex, or against a hex
+// fragment when format is "hex".
+func matchResponse(response []byte, expect, format string) (bool, error) {
+	switch strings.ToLower(format) {
+	case "", "text", "regex":
+		re, err := regexp.Compile(expect)
+		if err != nil {
+			return false, err
+		}
+		return re.Match(response), nil
+	case "hex":
+		fragment := strings.ToLower(strings.ReplaceAll(expect, " ", ""))
+		if _, err := hex.DecodeString(fragment); err != nil {
+			return false, err
+		}
+		return strings.Contains(hex.EncodeToString(response), fragment), nil
+	default:
+		return false, fmt.Errorf("unknown expect format '%s'", format)
+	}
+}
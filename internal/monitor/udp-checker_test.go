@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+// udpEcho answers every datagram with reply.
+func udpEcho(t *testing.T, reply []byte) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo(reply, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestCheckUDPResponseEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		reply    []byte
+		wantText bool
+	}{
+		{name: "text", reply: []byte("PONG\r\n"), wantText: true},
+		{name: "nul byte", reply: []byte("PONG\x00"), wantText: false},
+		{name: "control byte", reply: []byte("\x1b[31mPONG"), wantText: false},
+		{name: "invalid utf-8", reply: []byte{0xff, 0xfe, 'P'}, wantText: false},
+		{name: "long multi-byte", reply: []byte("P" + strings.Repeat("é", 200)), wantText: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mon := models.Monitor{Target: udpEcho(t, tt.reply), Timeout: 2 * time.Second}
+
+			res := checkUDP(context.Background(), mon)
+			if res.Status != models.StatusUp {
+				t.Fatalf("status = %s (%s), want up", res.Status, res.Message)
+			}
+
+			if !utf8.ValidString(res.ResultValue) || strings.ContainsRune(res.ResultValue, 0) {
+				t.Fatalf("result value %q cannot be stored as text", res.ResultValue)
+			}
+			if len(res.ResultValue) > 255+len("...") {
+				t.Fatalf("result value is %d bytes long", len(res.ResultValue))
+			}
+
+			var details models.UDPDetails
+			if err := json.Unmarshal(res.Details, &details); err != nil {
+				t.Fatal(err)
+			}
+			if (details.ResponseText != "") != tt.wantText {
+				t.Fatalf("response text = %q, want text: %v", details.ResponseText, tt.wantText)
+			}
+			if !tt.wantText && !strings.HasPrefix(res.ResultValue, details.ResponseHex[:4]) {
+				t.Fatalf("binary response not stored as hex: %q", res.ResultValue)
+			}
+		})
+	}
+}
+
+func TestTruncateKeepsRunesWhole(t *testing.T) {
+	got := truncate("aé", 2)
+	if got != "a..." {
+		t.Fatalf("truncate = %q, want %q", got, "a...")
+	}
+	if got := truncate("short", 10); got != "short" {
+		t.Fatalf("truncate = %q, want unchanged", got)
+	}
+}
@@ -0,0 +1,385 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"github.com/go-playground/validator/v10"
+)
+
+var configValidator = validator.New()
+
+// decodeConfig unmarshals config into req and runs its struct tag rules.
+// Errors are meant to be shown to API users as is.
+func decodeConfig(kind string, config json.RawMessage, req any) error {
+	if err := json.Unmarshal(config, req); err != nil {
+		return fmt.Errorf("Invalid %s config.", kind)
+	}
+
+	err := configValidator.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fmt.Errorf("Invalid %s config.", kind)
+	}
+
+	fields := make([]string, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		if e.Param() != "" {
+			fields = append(fields, fmt.Sprintf("%s: %s=%s", e.Field(), e.Tag(), e.Param()))
+		} else {
+			fields = append(fields, fmt.Sprintf("%s: %s", e.Field(), e.Tag()))
+		}
+	}
+
+	return fmt.Errorf("Invalid %s config (%s).", kind, strings.Join(fields, ", "))
+}
+
+func validateHTTPConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req models.HTTPConfig
+	if err := decodeConfig("HTTP", config, &req); err != nil {
+		return err
+	}
+
+	return validateHTTPOptions(req)
+}
+
+// validateHTTPOptions checks the parts of an HTTP config that would
+// otherwise only fail once the check runs. Content and transaction steps
+// embed the same options.
+func validateHTTPOptions(config models.HTTPConfig) error {
+	if strings.TrimSpace(config.AcceptedStatusCodes) != "" {
+		if _, err := parseStatusRanges(config.AcceptedStatusCodes); err != nil {
+			return fmt.Errorf("Invalid accepted_status_codes: %s.", err.Error())
+		}
+	}
+
+	if config.MaxRedirects < 0 {
+		return errors.New("Invalid max_redirects.")
+	}
+
+	if err := validateHTTPAuth(config.Auth); err != nil {
+		return err
+	}
+
+	for i, a := range config.Assertions {
+		if err := validateAssertion(a); err != nil {
+			return fmt.Errorf("Invalid assertion #%d: %s.", i+1, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func validateHTTPAuth(auth *models.HTTPAuth) error {
+	if auth == nil {
+		return nil
+	}
+
+	switch auth.Type {
+	case models.AuthBasic, models.AuthBearer:
+		return nil
+	default:
+		return fmt.Errorf("Invalid auth type '%s'.", auth.Type)
+	}
+}
+
+func validateAssertion(a models.HTTPAssertion) error {
+	switch a.Type {
+	case models.AssertContains, models.AssertNotContains:
+		return nil
+
+	case models.AssertRegex:
+		if _, err := regexp.Compile(a.Value); err != nil {
+			return fmt.Errorf("invalid regex '%s'", a.Value)
+		}
+		return nil
+
+	case models.AssertJSONPath:
+		if strings.TrimSpace(a.Path) == "" {
+			return errors.New("path is required")
+		}
+
+		switch a.Operator {
+		case "", "eq", "ne", "exists":
+		case "gt", "gte", "lt", "lte":
+			if _, err := strconv.ParseFloat(a.Value, 64); err != nil && !strings.Contains(a.Value, "{{") {
+				return fmt.Errorf("value '%s' is not a number", a.Value)
+			}
+		default:
+			return fmt.Errorf("unknown operator '%s'", a.Operator)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown type '%s'", a.Type)
+	}
+}
+
+func validateTLSConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req models.TLSConfig
+	if err := decodeConfig("TLS", config, &req); err != nil {
+		return err
+	}
+
+	if _, ok := defaultTLSPorts[req.StartTLS]; !ok {
+		return fmt.Errorf("Invalid starttls protocol '%s'.", req.StartTLS)
+	}
+
+	return nil
+}
+
+func validatePingConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	return decodeConfig("ICMP", config, &pingConfigRequest{})
+}
+
+func validateUDPConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req models.UDPConfig
+	if err := decodeConfig("UDP", config, &req); err != nil {
+		return err
+	}
+
+	if _, err := decodePayload(req.Payload, req.PayloadFormat); err != nil {
+		return fmt.Errorf("Invalid payload: %s.", err.Error())
+	}
+
+	if req.Expect != "" {
+		if _, err := matchResponse(nil, req.Expect, req.ExpectFormat); err != nil {
+			return fmt.Errorf("Invalid expect: %s.", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func validateGRPCConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	return decodeConfig("gRPC", config, &models.GRPCConfig{})
+}
+
+func validateDNSConfig(config json.RawMessage) error {
+	var req dnsConfigRequest
+	if err := decodeConfig("DNS", config, &req); err != nil {
+		return err
+	}
+
+	if req.SerialMaxAge != "" {
+		if _, err := time.ParseDuration(req.SerialMaxAge); err != nil {
+			return errors.New("Invalid serial_max_age duration.")
+		}
+	}
+
+	if strings.EqualFold(req.Mode, DNSModeDeliverability) && len(req.Resolvers) > 1 {
+		return errors.New("Deliverability mode queries a single resolver.")
+	}
+
+	return nil
+}
+
+func validateHeartbeatConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req models.HeartbeatConfig
+	if err := decodeConfig("heartbeat", config, &req); err != nil {
+		return err
+	}
+
+	if req.GracePeriod != "" {
+		if _, err := time.ParseDuration(req.GracePeriod); err != nil {
+			return errors.New("Invalid grace_period duration.")
+		}
+	}
+
+	return nil
+}
+
+func validateDomainConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	return decodeConfig("domain", config, &domainConfigRequest{})
+}
+
+func validateDatabaseConfig(config json.RawMessage) error {
+	return decodeConfig("database", config, &databaseConfigRequest{})
+}
+
+func validateRedisConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	return decodeConfig("Redis", config, &redisConfigRequest{})
+}
+
+func validateMailConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	return decodeConfig("mail", config, &mailConfigRequest{})
+}
+
+func validateWebSocketConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req models.WebSocketConfig
+	if err := decodeConfig("WebSocket", config, &req); err != nil {
+		return err
+	}
+
+	if _, err := regexp.Compile(req.Expect); err != nil {
+		return errors.New("Invalid expect pattern.")
+	}
+
+	return nil
+}
+
+func validateTransactionConfig(config json.RawMessage) error {
+	if err := decodeConfig("transaction", config, &transactionConfigRequest{}); err != nil {
+		return err
+	}
+
+	var req models.TransactionConfig
+	if err := json.Unmarshal(config, &req); err != nil {
+		return errors.New("Invalid transaction config.")
+	}
+
+	for i, step := range req.Steps {
+		if err := validateHTTPOptions(step.HTTPConfig); err != nil {
+			return fmt.Errorf("Step %d: %s", i+1, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func validateContentConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req models.ContentConfig
+	if err := decodeConfig("content", config, &req); err != nil {
+		return err
+	}
+
+	if err := validateHTTPOptions(req.HTTPConfig); err != nil {
+		return err
+	}
+
+	if _, err := parseSelector(req.Selector); req.Selector != "" && err != nil {
+		return fmt.Errorf("Invalid selector: %s.", err.Error())
+	}
+
+	if _, err := regexp.Compile(req.Pattern); err != nil {
+		return errors.New("Invalid pattern.")
+	}
+
+	for _, pattern := range req.IgnorePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("Invalid ignore pattern '%s'.", pattern)
+		}
+	}
+
+	return nil
+}
+
+func validatePrometheusConfig(config json.RawMessage) error {
+	if err := decodeConfig("Prometheus", config, &prometheusConfigRequest{}); err != nil {
+		return err
+	}
+
+	var req models.PrometheusConfig
+	if err := json.Unmarshal(config, &req); err != nil {
+		return errors.New("Invalid Prometheus config.")
+	}
+
+	return validateHTTPAuth(req.Auth)
+}
+
+func validateSSHConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	return decodeConfig("SSH", config, &sshConfigRequest{})
+}
+
+func validateNTPConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req ntpConfigRequest
+	if err := decodeConfig("NTP", config, &req); err != nil {
+		return err
+	}
+
+	if req.DegradedOffset > 0 && req.DownOffset > 0 && req.DegradedOffset > req.DownOffset {
+		return errors.New("Invalid thresholds: degraded_offset_ms is greater than down_offset_ms.")
+	}
+
+	return nil
+}
+
+func validateDNSBLConfig(config json.RawMessage) error {
+	if len(config) == 0 {
+		return nil
+	}
+
+	var req dnsblConfigRequest
+	if err := decodeConfig("DNSBL", config, &req); err != nil {
+		return err
+	}
+
+	if req.Resolver == "" || !isPublicResolver(req.Resolver) {
+		return nil
+	}
+
+	zones := req.Zones
+	if len(zones) == 0 {
+		zones = DefaultDNSBLZones
+	}
+
+	for _, zone := range zones {
+		if isSpamhausZone(zone) {
+			return fmt.Errorf("Spamhaus refuses queries from public resolvers such as %s; use your own resolver or remove %s.", req.Resolver, zone)
+		}
+	}
+
+	return nil
+}
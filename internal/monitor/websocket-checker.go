@@ -0,0 +1,199 @@
+package monitor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"golang.org/x/net/websocket"
+)
+
+const MaxWebSocketPreview = 256
+
+func checkWebSocket(ctx context.Context, m models.Monitor) models.CheckResult {
+	var config models.WebSocketConfig
+	if len(m.Config) > 0 {
+		if err := json.Unmarshal(m.Config, &config); err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: "[ERROR] WebSocket configuration error.", CheckedAt: time.Now()}
+		}
+	}
+
+	var expect *regexp.Regexp
+	if config.Expect != "" {
+		re, err := regexp.Compile(config.Expect)
+		if err != nil {
+			return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid expect pattern: %s", err.Error()), CheckedAt: time.Now()}
+		}
+		expect = re
+	}
+
+	target, origin, err := websocketURLs(m.Target, config.Origin)
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid WebSocket URL: %s", err.Error()), CheckedAt: time.Now()}
+	}
+
+	wsConfig, err := websocket.NewConfig(target, origin)
+	if err != nil {
+		return models.CheckResult{MonitorID: m.ID, Status: models.StatusDown, Message: fmt.Sprintf("Invalid WebSocket URL: %s", err.Error()), CheckedAt: time.Now()}
+	}
+
+	wsConfig.Dialer = &net.Dialer{Timeout: m.Timeout}
+	for key, value := range config.Headers {
+		wsConfig.Header.Set(key, value)
+	}
+	if config.Subprotocol != "" {
+		wsConfig.Protocol = []string{config.Subprotocol}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.Timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	ws, err := wsConfig.DialContext(ctx)
+	if err != nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusDown,
+			Latency:   time.Since(start).Milliseconds(),
+			Message:   fmt.Sprintf("WebSocket handshake failed: %s", err.Error()),
+			CheckedAt: time.Now(),
+		}
+	}
+	defer ws.Close()
+	defer closeOnCancel(ctx, ws)()
+
+	details := models.WebSocketDetails{HandshakeTime: time.Since(start).Milliseconds()}
+
+	if config.Message == "" && expect == nil {
+		return models.CheckResult{
+			MonitorID: m.ID,
+			Status:    models.StatusUp,
+			Latency:   details.HandshakeTime,
+			Message:   "WebSocket handshake completed",
+			Details:   marshalDetails(details),
+			CheckedAt: time.Now(),
+		}
+	}
+
+	ws.MaxPayloadBytes = MaxBodySize
+	_ = ws.SetDeadline(start.Add(m.Timeout))
+
+	sentAt := time.Now()
+
+	if config.Message != "" {
+		if err := websocket.Message.Send(ws, config.Message); err != nil {
+			return models.CheckResult{
+				MonitorID: m.ID,
+				Status:    models.StatusDown,
+				Latency:   details.HandshakeTime,
+				Message:   fmt.Sprintf("Failed to send message: %s", err.Error()),
+				Details:   marshalDetails(details),
+				CheckedAt: time.Now(),
+			}
+		}
+	}
+
+	// Servers often push unrelated events, so keep reading until a message
+	// matches or the deadline passes.
+	var last string
+	for {
+		var reply string
+		if err := websocket.Message.Receive(ws, &reply); err != nil {
+			message := fmt.Sprintf("No reply received: %s", err.Error())
+			if expect != nil && last != "" {
+				message = fmt.Sprintf("No reply matched /%s/ (last: '%s')", config.Expect, last)
+			}
+
+			return models.CheckResult{
+				MonitorID: m.ID,
+				Status:    models.StatusDown,
+				Latency:   details.HandshakeTime,
+				Message:   message,
+				Details:   marshalDetails(details),
+				CheckedAt: time.Now(),
+			}
+		}
+
+		last = replyPreview(reply)
+
+		if expect == nil || expect.MatchString(reply) {
+			break
+		}
+	}
+
+	details.RoundTrip = time.Since(sentAt).Milliseconds()
+	details.Response = last
+
+	return models.CheckResult{
+		MonitorID:   m.ID,
+		Status:      models.StatusUp,
+		Latency:     details.HandshakeTime,
+		ResultValue: last,
+		Message:     fmt.Sprintf("Reply received in %dms", details.RoundTrip),
+		Details:     marshalDetails(details),
+		CheckedAt:   time.Now(),
+	}
+}
+
+// websocketURLs normalizes the target to a ws:// or wss:// URL and derives
+// the Origin header from it when none is configured.
+func websocketURLs(target, origin string) (string, string, error) {
+	if !strings.Contains(target, "://") {
+		target = "ws://" + target
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	case "ws", "wss":
+	default:
+		return "", "", fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	}
+
+	if origin == "" {
+		scheme := "http"
+		if u.Scheme == "wss" {
+			scheme = "https"
+		}
+		origin = scheme + "://" + u.Host
+	}
+
+	return u.String(), origin, nil
+}
+
+// replyPreview is the part of a reply kept in results and alerts. Binary
+// frames are shown as hex, like UDP responses.
+func replyPreview(reply string) string {
+	if !isPrintableText([]byte(reply)) {
+		reply = hex.EncodeToString([]byte(reply))
+	}
+	return truncate(reply, MaxWebSocketPreview)
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ghduuep/pingly/internal/models"
+	"golang.org/x/net/websocket"
+)
+
+func TestCheckWebSocketBinaryReply(t *testing.T) {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var msg string
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return
+		}
+		_ = websocket.Message.Send(ws, []byte("<b>\x00\xff"))
+	}))
+	defer srv.Close()
+
+	config, _ := json.Marshal(models.WebSocketConfig{Message: "ping", Expect: "pong"})
+	mon := models.Monitor{Target: strings.Replace(srv.URL, "http", "ws", 1), Timeout: 2 * time.Second, Config: config}
+
+	res := checkWebSocket(context.Background(), mon)
+	if res.Status != models.StatusDown {
+		t.Fatalf("status = %s, want down", res.Status)
+	}
+
+	if !strings.Contains(res.Message, "3c623e00ff") {
+		t.Fatalf("message %q does not show the reply as hex", res.Message)
+	}
+	if !utf8.ValidString(res.Message) || strings.ContainsRune(res.Message, 0) {
+		t.Fatalf("message %q cannot be stored as text", res.Message)
+	}
+}
+
+func TestCheckWebSocketTextReply(t *testing.T) {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var msg string
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return
+		}
+		_ = websocket.Message.Send(ws, "pong")
+	}))
+	defer srv.Close()
+
+	config, _ := json.Marshal(models.WebSocketConfig{Message: "ping", Expect: "^pong$"})
+	mon := models.Monitor{Target: srv.URL, Timeout: 2 * time.Second, Config: config}
+
+	res := checkWebSocket(context.Background(), mon)
+	if res.Status != models.StatusUp || res.ResultValue != "pong" {
+		t.Fatalf("got %s %q (%s), want up with reply pong", res.Status, res.ResultValue, res.Message)
+	}
+}
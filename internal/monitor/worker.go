@@ -28,6 +28,7 @@ func (m *MonitorManager) runWorker(ctx context.Context, mon models.Monitor) {
 	if useFastInterval || initialStatus == models.StatusDown || initialStatus == models.StatusDegraded {
 		initialDelay = DownCheckInterval
 	}
+	initialDelay = pollDelay(mon, initialDelay)
 
 	timer := time.NewTimer(initialDelay)
 	defer timer.Stop()
@@ -43,6 +44,7 @@ func (m *MonitorManager) runWorker(ctx context.Context, mon models.Monitor) {
 			if useFastInterval {
 				nextInterval = DownCheckInterval
 			}
+			nextInterval = pollDelay(mon, nextInterval)
 
 			timer.Reset(nextInterval)
 		}
@@ -50,12 +52,27 @@ func (m *MonitorManager) runWorker(ctx context.Context, mon models.Monitor) {
 }
 
 func (m *MonitorManager) processCheck(ctx context.Context, mon *models.Monitor) (models.MonitorStatus, bool) {
-	result := performCheck(*mon)
+	result := m.performCheck(ctx, *mon)
+
+	// A check cut short by a stop or shutdown says nothing about the target,
+	// so it is neither persisted nor alerted on.
+	if ctx.Err() != nil {
+		log.Printf("[INFO] Check for monitor %d cancelled", mon.ID)
+		return mon.LastCheckStatus, false
+	}
 
 	m.handleDNSLearning(ctx, mon, &result)
 
+	m.handleSSHLearning(ctx, mon, &result)
+
+	m.handleSOASerial(ctx, mon, &result)
+
 	m.handleSSLAlerts(ctx, mon, &result)
 
+	m.handleDomainExpiry(ctx, mon, &result)
+
+	m.handleContentChange(ctx, mon, &result)
+
 	shouldProceed := m.isConfirmedFailure(ctx, mon, result.Status)
 	if !shouldProceed {
 		_ = database.UpdateLastCheck(ctx, m.db, mon.ID)
@@ -79,20 +96,25 @@ func (m *MonitorManager) processCheck(ctx context.Context, mon *models.Monitor)
 	return result.Status, isDownOrDegraded
 }
 
-func performCheck(m models.Monitor) models.CheckResult {
-	switch m.Type {
-	case models.TypeHTTP:
-		return checkHTTP(m)
-	case models.TypeDNS:
-		return checkDNS(m)
-	case models.TypePort:
-		return checkPort(m)
-	default:
+// pollDelay caps the delay for heartbeat monitors so a missed ping is noticed
+// shortly after its deadline rather than a full interval later.
+func pollDelay(mon models.Monitor, delay time.Duration) time.Duration {
+	if mon.Type == models.TypeHeartbeat && delay > HeartbeatPollInterval {
+		return HeartbeatPollInterval
+	}
+	return delay
+}
+
+func (m *MonitorManager) performCheck(ctx context.Context, mon models.Monitor) models.CheckResult {
+	checker, ok := Lookup(mon.Type)
+	if !ok {
 		return models.CheckResult{
-			MonitorID: m.ID,
+			MonitorID: mon.ID,
 			Status:    models.StatusDown,
 			Message:   "Unknown monitor type",
 			CheckedAt: time.Now(),
 		}
 	}
+
+	return checker.Check(ctx, mon)
 }
@@ -42,30 +42,7 @@ func (s *EmailService) Send(to, subject, body string) error {
 }
 
 func (s *EmailService) SendStatusAlert(to string, m models.Monitor, result models.CheckResult, inc *models.Incident) error {
-	var subject, body string
-
-	if m.Type == models.TypeHTTP {
-		subject, body = templates.BuildEmailHTTPMessage(m, result, inc)
-	} else if m.Type == models.TypeDNS {
-		var config models.DNSConfig
-		var dnsType string
-		if err := json.Unmarshal(m.Config, &config); err == nil {
-			dnsType = config.RecordType
-		} else {
-			dnsType = "N/A"
-		}
-
-		if result.Status == models.StatusUp {
-			subject, body = templates.BuildEmailDNSRecoveredMessage(m, result, dnsType, inc)
-		} else if result.Status == models.StatusDown && result.ResultValue != "" {
-			subject, body = templates.BuildEmailDNSChangedMessage(m, result, dnsType)
-		} else {
-			subject, body = templates.BuildEmailDNSStatusMessage(m, result, dnsType)
-		}
-	} else if m.Type == models.TypePort {
-		subject, body = templates.BuildEmailPortMessage(m, result, inc)
-	}
-
+	subject, body := templates.Lookup(m.Type).Email(m, result, inc)
 	return s.Send(to, subject, body)
 }
 
@@ -98,26 +75,7 @@ func (t *TelegramService) Send(to, subject, body string) error {
 }
 
 func (t *TelegramService) SendStatusAlert(chatID string, m models.Monitor, result models.CheckResult, inc *models.Incident) error {
-	var subject, body string
-
-	if m.Type == models.TypeHTTP {
-		subject, body = templates.BuildTelegramHTTPMessage(m, result, inc)
-	} else if m.Type == models.TypeDNS {
-		var config models.DNSConfig
-		json.Unmarshal(m.Config, &config)
-
-		if result.Status == models.StatusUp {
-			subject, body = templates.BuildTelegramDNSRecoveredMessage(m, result, config.RecordType, inc)
-		} else if result.Status == models.StatusDown && result.ResultValue != "" {
-			subject, body = templates.BuildTelegramDNSChangedMessage(m, result, config.RecordType)
-		} else {
-			subject, body = templates.BuildTelegramDNSStatusMessage(m, result, config.RecordType)
-		}
-
-	} else if m.Type == models.TypePort {
-		subject, body = templates.BuildTelegramPortMessage(m, result, inc)
-	}
-
+	subject, body := templates.Lookup(m.Type).Telegram(m, result, inc)
 	return t.Send(chatID, subject, body)
 }
 
@@ -151,24 +109,6 @@ func (s *SMSService) Send(to, body string) error {
 }
 
 func (s *SMSService) SendStatusAlert(to string, m models.Monitor, result models.CheckResult, inc *models.Incident) error {
-	var body string
-
-	if m.Type == models.TypeHTTP {
-		body = templates.BuildSMSHTTPMessage(m, result, inc)
-	} else if m.Type == models.TypeDNS {
-		var config models.DNSConfig
-		json.Unmarshal(m.Config, &config)
-
-		if result.Status == models.StatusUp {
-			body = templates.BuildSMSDNSRecoveredMessage(m, result, config.RecordType)
-		} else if result.Status == models.StatusDown && result.ResultValue != "" {
-			body = templates.BuildSMSDNSChangedMessage(m, result, config.RecordType)
-		} else {
-			body = templates.BuildSMSDNSStatusMessage(m, result, config.RecordType)
-		}
-	} else if m.Type == models.TypePort {
-		body = templates.BuildSMSPortMessage(m, result, inc)
-	}
-
+	body := templates.Lookup(m.Type).SMS(m, result, inc)
 	return s.Send(to, body)
 }
@@ -1,7 +1,10 @@
 package templates
 
 import (
+	"encoding/json"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/ghduuep/pingly/internal/models"
@@ -62,10 +65,15 @@ func buildBaseEmail(title, badgeText, badgeColor, target, bodyContent string) st
 		</div>
 	</body>
 	</html>
-	`, colorBg, fontFamily, colorWhite, colorSlate100, colorSlate900, badgeColor, badgeColor, badgeText, colorSlate900, title, colorSlate500, target, colorSlate100, bodyContent, colorSlate100, colorSlate400)
+	`, colorBg, fontFamily, colorWhite, colorSlate100, colorSlate900, badgeColor, badgeColor, badgeText, colorSlate900, title, colorSlate500, html.EscapeString(target), colorSlate100, bodyContent, colorSlate100, colorSlate400)
 }
 
+// buildRow renders one labelled value. Values carry text from the monitored
+// services, so both are escaped here and newlines become line breaks.
 func buildRow(label, value string, isMono bool) string {
+	label = html.EscapeString(label)
+	value = strings.ReplaceAll(html.EscapeString(value), "\n", "<br>")
+
 	fontStack := fontFamily
 	if isMono {
 		fontStack = `'SFMono-Regular', Consolas, 'Liberation Mono', Menlo, monospace`
@@ -118,9 +126,32 @@ func BuildEmailHTTPMessage(m models.Monitor, res models.CheckResult, inc *models
 	return subject, body
 }
 
+// BuildEmailDNSMessage picks the DNS template matching the mode of the monitor
+// and the outcome of the check.
+func BuildEmailDNSMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var config models.DNSConfig
+	dnsType := "N/A"
+	if err := json.Unmarshal(m.Config, &config); err == nil {
+		dnsType = config.RecordType
+	}
+
+	switch {
+	case config.Mode == "deliverability":
+		return BuildEmailDeliverabilityMessage(m, res, inc)
+	case res.Status == models.StatusUp:
+		return BuildEmailDNSRecoveredMessage(m, res, dnsType, inc)
+	case res.Status == models.StatusDown && res.ResultValue != "":
+		return BuildEmailDNSChangedMessage(m, res, dnsType)
+	case res.Status == models.StatusDegraded:
+		return BuildEmailDNSWarningMessage(m, res, dnsType)
+	default:
+		return BuildEmailDNSStatusMessage(m, res, dnsType)
+	}
+}
+
 func BuildEmailDNSRecoveredMessage(m models.Monitor, res models.CheckResult, dnsType string, inc *models.Incident) (string, string) {
 	content := buildRow("Record Type", dnsType, true)
-	content += buildRow("Resolved Value", res.ResultValue, true)
+	content += buildRow(dnsValueLabel(dnsType), res.ResultValue, true)
 
 	if inc != nil && inc.Duration != nil {
 		content += buildRow("Instability Duration", inc.Duration.Round(time.Second).String(), false)
@@ -144,7 +175,13 @@ func BuildEmailDNSStatusMessage(m models.Monitor, res models.CheckResult, dnsTyp
 
 func BuildEmailDNSChangedMessage(m models.Monitor, res models.CheckResult, dnsType string) (string, string) {
 	content := buildRow("Record Type", dnsType, true)
-	content += buildRow("New Value Detected", res.ResultValue, true)
+
+	if disagreeing := disagreeingServers(res); len(disagreeing) > 0 {
+		content += buildRow("Disagreeing Nameservers", strings.Join(disagreeing, "\n"), true)
+	} else {
+		content += buildRow("New "+dnsValueLabel(dnsType), res.ResultValue, true)
+	}
+
 	content += buildRow("Alert Message", res.Message, false)
 
 	subject := fmt.Sprintf("[ALERT] DNS Modified: %s", m.Target)
@@ -153,6 +190,115 @@ func BuildEmailDNSChangedMessage(m models.Monitor, res models.CheckResult, dnsTy
 	return subject, body
 }
 
+func BuildEmailDNSWarningMessage(m models.Monitor, res models.CheckResult, dnsType string) (string, string) {
+	content := buildRow("Record Type", dnsType, true)
+	content += buildRow(dnsValueLabel(dnsType), res.ResultValue, true)
+	content += buildRow("Alert Message", res.Message, false)
+
+	subject := fmt.Sprintf("[WARNING] DNS Health: %s", m.Target)
+	body := buildBaseEmail("DNS Warning", "ATTENTION", colorAmber, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailDeliverabilityMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "INVALID RECORDS"
+		title = "Email Authentication Broken"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "WEAK POLICY"
+		title = "Email Authentication Below Policy"
+	default:
+		color = colorGreen
+		statusText = "RESOLVED"
+		title = "Email Authentication Valid"
+	}
+
+	var details models.DeliverabilityDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	spf := details.SPF.Error
+	if spf == "" {
+		spf = fmt.Sprintf("%s (%d DNS lookups)", details.SPF.All, details.SPF.Lookups)
+	}
+	content := buildRow("SPF", spf, true)
+
+	dmarc := details.DMARC.Error
+	if dmarc == "" {
+		dmarc = fmt.Sprintf("p=%s, pct=%d", details.DMARC.Policy, details.DMARC.Percent)
+	}
+	content += buildRow("DMARC", dmarc, true)
+
+	for _, key := range details.DKIM {
+		value := key.Error
+		if value == "" {
+			value = fmt.Sprintf("%s %d-bit", key.KeyType, key.KeyBits)
+		}
+		content += buildRow("DKIM "+key.Selector, value, true)
+	}
+
+	if res.Status != models.StatusUp {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] Email Deliverability: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+// disagreeingServers lists the authoritative nameservers that did not serve
+// the expected value in a propagation check.
+func disagreeingServers(res models.CheckResult) []string {
+	var details models.DNSDetails
+	if err := json.Unmarshal(res.Details, &details); err != nil || details.Mode != "propagation" {
+		return nil
+	}
+
+	var servers []string
+	for _, a := range details.Answers {
+		if a.Matches {
+			continue
+		}
+
+		value := a.Value
+		if a.Error != "" {
+			value = a.Error
+		}
+		servers = append(servers, fmt.Sprintf("%s: %s", a.Resolver, value))
+	}
+
+	return servers
+}
+
+func dnsValueLabel(dnsType string) string {
+	switch dnsType {
+	case "SOA":
+		return "Zone Serial"
+	case "PTR":
+		return "Reverse Name"
+	case "SRV":
+		return "Service Targets"
+	case "CAA":
+		return "CA Authorization"
+	case "DS", "DNSKEY":
+		return "DNSSEC Records"
+	default:
+		return "Resolved Value"
+	}
+}
+
 func BuildEmailPortMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
 	var color, statusText, title string
 
@@ -188,3 +334,793 @@ func BuildEmailPortMessage(m models.Monitor, res models.CheckResult, inc *models
 
 	return subject, body
 }
+
+func BuildEmailTLSMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "CERTIFICATE INVALID"
+		title = "TLS Check Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "EXPIRING SOON"
+		title = "Certificate Expiring"
+	default:
+		color = colorGreen
+		statusText = "VALID"
+		title = "Certificate Valid"
+	}
+
+	var details models.TLSDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := ""
+	if details.Issuer != "" {
+		content += buildRow("Issuer", details.Issuer, false)
+		content += buildRow("Expires", fmt.Sprintf("%s (%d days)", details.NotAfter.Format("02/01/2006"), details.DaysRemaining), false)
+		content += buildRow("Protocol", fmt.Sprintf("%s / %s", details.TLSVersion, details.KeyType), true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Incident Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] TLS Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailICMPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "HOST UNREACHABLE"
+		title = "Ping Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "PACKET LOSS"
+		title = "Unstable Network"
+	default:
+		color = colorGreen
+		statusText = "REACHABLE"
+		title = "Host Responding"
+	}
+
+	var details models.PingDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := buildRow("Packet Loss", fmt.Sprintf("%.1f%% (%d/%d received)", details.PacketLoss, details.Received, details.Sent), true)
+	if details.Received > 0 {
+		content += buildRow("Round Trip (min/avg/max)", fmt.Sprintf("%.1f / %.1f / %.1f ms", details.MinRTT, details.AvgRTT, details.MaxRTT), true)
+		content += buildRow("Jitter", fmt.Sprintf("%.1f ms", details.Jitter), true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] Ping Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailUDPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "NO RESPONSE"
+		title = "UDP Service Down"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "HIGH LATENCY"
+		title = "Slow UDP Response"
+	default:
+		color = colorGreen
+		statusText = "RESPONDING"
+		title = "UDP Service Responding"
+	}
+
+	content := buildRow("Round Trip", fmt.Sprintf("%dms", res.Latency), true)
+
+	if res.ResultValue != "" {
+		content += buildRow("Response", res.ResultValue, true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] UDP Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailHeartbeatMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "MISSED"
+		title = "Heartbeat Missed"
+	default:
+		color = colorGreen
+		statusText = "RECEIVED"
+		title = "Heartbeat Received"
+	}
+
+	content := ""
+
+	if res.ResultValue != "" {
+		content += buildRow("Last Signal", res.ResultValue, true)
+	}
+
+	if res.Status == models.StatusUp && res.Latency > 0 {
+		content += buildRow("Job Duration", (time.Duration(res.Latency) * time.Millisecond).String(), true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] Heartbeat Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailDomainMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "DOMAIN AT RISK"
+		title = "Domain Check Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "EXPIRING SOON"
+		title = "Domain Expiring"
+	default:
+		color = colorGreen
+		statusText = "REGISTERED"
+		title = "Domain Registered"
+	}
+
+	var details models.DomainDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := ""
+	if details.Registrar != "" {
+		content += buildRow("Registrar", details.Registrar, false)
+	}
+
+	if !details.ExpiresAt.IsZero() {
+		content += buildRow("Expires", fmt.Sprintf("%s (%d days)", details.ExpiresAt.Format("02/01/2006"), details.DaysRemaining), false)
+	}
+
+	if len(details.Status) > 0 {
+		content += buildRow("Status", strings.Join(details.Status, ", "), true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Incident Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] Domain Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func databaseLabel(monitorType models.MonitorType) string {
+	switch monitorType {
+	case models.TypePostgres:
+		return "PostgreSQL"
+	case models.TypeMySQL:
+		return "MySQL"
+	case models.TypeRedis:
+		return "Redis"
+	default:
+		return strings.ToUpper(string(monitorType))
+	}
+}
+
+func BuildEmailDatabaseMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	label := databaseLabel(m.Type)
+
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "UNAVAILABLE"
+		title = fmt.Sprintf("%s Check Failed", label)
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "SLOW QUERY"
+		title = fmt.Sprintf("Slow %s Response", label)
+	default:
+		color = colorGreen
+		statusText = "ACCEPTING QUERIES"
+		title = fmt.Sprintf("%s Operational", label)
+	}
+
+	var details models.DatabaseDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := buildRow("Query Latency", fmt.Sprintf("%dms", res.Latency), true)
+
+	if details.ServerVersion != "" {
+		content += buildRow("Server Version", details.ServerVersion, true)
+	}
+
+	if details.Role != "" {
+		content += buildRow("Role", details.Role, true)
+	}
+
+	if res.ResultValue != "" {
+		content += buildRow("Result", res.ResultValue, true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] %s Alert: %s", statusText, label, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailMailMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	label := strings.ToUpper(string(m.Type))
+
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "NOT ACCEPTING MAIL"
+		title = fmt.Sprintf("%s Server Down", label)
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "HIGH LATENCY"
+		title = fmt.Sprintf("Slow %s Server", label)
+	default:
+		color = colorGreen
+		statusText = "READY"
+		title = fmt.Sprintf("%s Server Ready", label)
+	}
+
+	var details models.MailDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := buildRow("Response Time", fmt.Sprintf("%dms", res.Latency), true)
+
+	if details.Banner != "" {
+		content += buildRow("Banner", details.Banner, true)
+	}
+
+	if details.TLSVersion != "" {
+		content += buildRow("Encryption", details.TLSVersion, true)
+	}
+
+	if len(details.AuthMechanisms) > 0 {
+		content += buildRow("AUTH", strings.Join(details.AuthMechanisms, ", "), true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] %s Alert: %s", statusText, label, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailGRPCMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "NOT SERVING"
+		title = "gRPC Service Down"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "UNKNOWN"
+		title = "gRPC Health Unknown"
+	default:
+		color = colorGreen
+		statusText = "SERVING"
+		title = "gRPC Service Serving"
+	}
+
+	var details models.GRPCDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := buildRow("Response Time", fmt.Sprintf("%dms", res.Latency), true)
+
+	if details.ServingStatus != "" {
+		content += buildRow("Serving Status", details.ServingStatus, true)
+	}
+
+	if details.GRPCStatus != 0 {
+		content += buildRow("gRPC Status", fmt.Sprintf("%d %s", details.GRPCStatus, details.GRPCMessage), true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] gRPC Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailWebSocketMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "UNREACHABLE"
+		title = "WebSocket Check Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "HIGH LATENCY"
+		title = "Slow WebSocket Handshake"
+	default:
+		color = colorGreen
+		statusText = "CONNECTED"
+		title = "WebSocket Operational"
+	}
+
+	var details models.WebSocketDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := buildRow("Handshake", fmt.Sprintf("%dms", res.Latency), true)
+
+	if details.RoundTrip > 0 {
+		content += buildRow("Round Trip", fmt.Sprintf("%dms", details.RoundTrip), true)
+	}
+
+	if details.Response != "" {
+		content += buildRow("Reply", details.Response, true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] WebSocket Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailTransactionMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "STEP FAILED"
+		title = "Transaction Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "HIGH LATENCY"
+		title = "Slow Transaction"
+	default:
+		color = colorGreen
+		statusText = "PASSING"
+		title = "Transaction Passing"
+	}
+
+	var details models.TransactionDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := buildRow("Total Time", fmt.Sprintf("%dms", res.Latency), true)
+
+	for i, step := range details.Steps {
+		label := fmt.Sprintf("Step %d", i+1)
+		if step.Name != "" {
+			label = fmt.Sprintf("Step %d · %s", i+1, step.Name)
+		}
+
+		value := fmt.Sprintf("%d · %dms", step.StatusCode, step.Duration)
+		if step.Error != "" {
+			value = fmt.Sprintf("FAILED after %dms: %s", step.Duration, step.Error)
+		}
+
+		content += buildRow(label, value, true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] Transaction Alert: %s", statusText, m.Target)
+	if details.FailedStep != nil {
+		subject = fmt.Sprintf("[%s] Transaction Alert: %s (step %d)", statusText, m.Target, *details.FailedStep)
+	}
+
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailContentMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var details models.ContentDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	if details.Diff != "" {
+		content := buildRow("Change", fmt.Sprintf("+%d / -%d lines", details.Added, details.Removed), true)
+		content += buildRow("Diff", details.Diff, true)
+		content += buildRow("New Hash", details.Hash, true)
+
+		subject := fmt.Sprintf("[CHANGED] Content Alert: %s", m.Target)
+		body := buildBaseEmail("Page Content Changed", "CHANGE DETECTED", colorAmber, m.Target, content)
+
+		return subject, body
+	}
+
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "UNREACHABLE"
+		title = "Content Check Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "HIGH LATENCY"
+		title = "Slow Page"
+	default:
+		color = colorGreen
+		statusText = "WATCHING"
+		title = "Content Check Operational"
+	}
+
+	content := buildRow("Response Time", fmt.Sprintf("%dms", res.Latency), true)
+
+	if details.Hash != "" {
+		content += buildRow("Content Hash", details.Hash, true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] Content Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailPrometheusMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "CRITICAL"
+		title = "Metric Check Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "THRESHOLD"
+		title = "Metric Above Warning Level"
+	default:
+		color = colorGreen
+		statusText = "HEALTHY"
+		title = "Metric Within Limits"
+	}
+
+	var details models.PrometheusDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := ""
+	if res.ResultValue != "" {
+		content += buildRow("Value", res.ResultValue, true)
+	}
+
+	if len(details.Series) > 0 {
+		content += buildRow("Series", strings.Join(details.Series, "\n"), true)
+	}
+
+	content += buildRow("Scrape Time", fmt.Sprintf("%dms", res.Latency), true)
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] Metric Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailSSHMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var details models.SSHDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	// The handshake completed, so a down result means the pinned key differs.
+	keyChanged := res.Status == models.StatusDown && details.Fingerprint != ""
+
+	var color, statusText, title string
+
+	switch {
+	case keyChanged:
+		color = colorRed
+		statusText = "SECURITY ALERT"
+		title = "SSH Host Key Changed"
+	case res.Status == models.StatusDown:
+		color = colorRed
+		statusText = "UNREACHABLE"
+		title = "SSH Check Failed"
+	case res.Status == models.StatusDegraded:
+		color = colorAmber
+		statusText = "HIGH LATENCY"
+		title = "Slow SSH Handshake"
+	default:
+		color = colorGreen
+		statusText = "VERIFIED"
+		title = "SSH Host Key Verified"
+	}
+
+	content := ""
+	if details.Banner != "" {
+		content += buildRow("Banner", details.Banner, true)
+	}
+
+	if details.Fingerprint != "" {
+		content += buildRow("Host Key", fmt.Sprintf("%s %s", details.KeyType, details.Fingerprint), true)
+	}
+
+	content += buildRow("Handshake", fmt.Sprintf("%dms", res.Latency), true)
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] SSH Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailNTPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "CLOCK FAILURE"
+		title = "NTP Check Failed"
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "CLOCK DRIFT"
+		title = "Clock Offset Warning"
+	default:
+		color = colorGreen
+		statusText = "IN SYNC"
+		title = "NTP Server Synchronized"
+	}
+
+	var details models.NTPDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := ""
+	if res.ResultValue != "" {
+		content += buildRow("Offset", res.ResultValue+"ms", true)
+		content += buildRow("Round-Trip Delay", fmt.Sprintf("%.3fms", details.Delay), true)
+		content += buildRow("Stratum", fmt.Sprintf("%d (ref %s)", details.Stratum, details.RefID), true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] NTP Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+func BuildEmailDNSBLMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "BLOCKLISTED"
+		title = "Address Listed on a Blocklist"
+		if res.ResultValue == "" {
+			statusText = "CHECK FAILED"
+			title = "Blocklist Check Failed"
+		}
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "PARTIAL CHECK"
+		title = "Some Blocklists Could Not Be Queried"
+	default:
+		color = colorGreen
+		statusText = "DELISTED"
+		title = "Not Listed on Any Blocklist"
+	}
+
+	var details models.DNSBLDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	content := ""
+	if len(details.Addresses) > 0 {
+		content += buildRow("Addresses", strings.Join(details.Addresses, ", "), true)
+	}
+
+	for _, r := range details.Results {
+		if !r.Listed {
+			continue
+		}
+
+		value := fmt.Sprintf("%s (%s)", r.Address, strings.Join(r.Codes, ", "))
+		if r.Reason != "" {
+			value += "\n" + r.Reason
+		}
+		content += buildRow("Listed on "+r.Zone, value, true)
+	}
+
+	if res.Status != models.StatusUp {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Listing Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] DNSBL Alert: %s", statusText, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
+
+// BuildEmailGenericMessage is used for monitor types without a dedicated
+// template, such as checkers registered outside this repository.
+func BuildEmailGenericMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	label := strings.ToUpper(string(m.Type))
+
+	var color, statusText, title string
+
+	switch res.Status {
+	case models.StatusDown:
+		color = colorRed
+		statusText = "DOWN"
+		title = fmt.Sprintf("%s Check Failed", label)
+	case models.StatusDegraded:
+		color = colorAmber
+		statusText = "DEGRADED"
+		title = fmt.Sprintf("%s Check Degraded", label)
+	default:
+		color = colorGreen
+		statusText = "UP"
+		title = fmt.Sprintf("%s Check Passing", label)
+	}
+
+	content := buildRow("Response Time", fmt.Sprintf("%dms", res.Latency), true)
+
+	if res.ResultValue != "" {
+		content += buildRow("Result", res.ResultValue, true)
+	}
+
+	if res.Message != "" {
+		content += buildRow("Diagnostic Trace", res.Message, false)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		content += `<div style="margin-top: 24px; padding-top: 24px; border-top: 1px solid #cbd5e1;">`
+		content += buildRow("Outage Duration", inc.Duration.Round(time.Second).String(), false)
+		content += "</div>"
+	}
+
+	subject := fmt.Sprintf("[%s] %s Alert: %s", statusText, label, m.Target)
+	body := buildBaseEmail(title, statusText, color, m.Target, content)
+
+	return subject, body
+}
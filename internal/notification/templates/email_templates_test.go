@@ -0,0 +1,73 @@
+package templates
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+const hostile = `<img src=x onerror="alert(1)">`
+
+func TestBuildRowEscapesValues(t *testing.T) {
+	row := buildRow("Label <b>", "line one\n"+hostile, true)
+
+	if strings.Contains(row, "<img") || strings.Contains(row, "<b>") {
+		t.Fatalf("row contains unescaped markup: %s", row)
+	}
+	if !strings.Contains(row, "line one<br>&lt;img") {
+		t.Fatalf("newline not rendered as a line break: %s", row)
+	}
+}
+
+func TestEmailTemplatesEscapeServerText(t *testing.T) {
+	details, _ := json.Marshal(models.MailDetails{Banner: hostile})
+
+	tests := []struct {
+		name  string
+		build func(models.Monitor, models.CheckResult, *models.Incident) (string, string)
+		mon   models.Monitor
+		res   models.CheckResult
+	}{
+		{
+			name:  "http assertion",
+			build: BuildEmailHTTPMessage,
+			mon:   models.Monitor{Type: models.TypeHTTP, Target: "https://example.com"},
+			res:   models.CheckResult{Status: models.StatusDown, Message: "Body assertion failed: got '" + hostile + "'"},
+		},
+		{
+			name:  "mail banner",
+			build: BuildEmailMailMessage,
+			mon:   models.Monitor{Type: models.TypeSMTP, Target: "mx.example.com:25"},
+			res:   models.CheckResult{Status: models.StatusDown, Message: "Unexpected greeting: " + hostile, Details: details},
+		},
+		{
+			name:  "websocket reply",
+			build: BuildEmailWebSocketMessage,
+			mon:   models.Monitor{Type: models.TypeWebSocket, Target: "wss://example.com/ws"},
+			res:   models.CheckResult{Status: models.StatusDown, Message: "No reply matched /pong/ (last: '" + hostile + "')"},
+		},
+		{
+			name:  "target",
+			build: BuildEmailPortMessage,
+			mon:   models.Monitor{Type: models.TypePort, Target: hostile},
+			res:   models.CheckResult{Status: models.StatusDown, Message: "Connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.res.CheckedAt = time.Now()
+
+			_, body := tt.build(tt.mon, tt.res, nil)
+			if strings.Contains(body, "<img") {
+				t.Fatalf("body contains unescaped server text")
+			}
+			if !strings.Contains(body, "&lt;img") {
+				t.Fatalf("body does not contain the escaped server text")
+			}
+		})
+	}
+}
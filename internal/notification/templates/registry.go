@@ -0,0 +1,76 @@
+package templates
+
+import (
+	"sync"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+// Builders render the alert of one monitor type for every channel.
+type Builders struct {
+	Email    func(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string)
+	Telegram func(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string)
+	SMS      func(m models.Monitor, res models.CheckResult, inc *models.Incident) string
+}
+
+var genericBuilders = Builders{
+	Email:    BuildEmailGenericMessage,
+	Telegram: BuildTelegramGenericMessage,
+	SMS:      BuildSMSGenericMessage,
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[models.MonitorType]Builders)
+)
+
+// Register sets the alert templates of a monitor type. Registering a type
+// again replaces its templates.
+func Register(monitorType models.MonitorType, builders Builders) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[monitorType] = builders
+}
+
+// Lookup returns the alert templates of a monitor type, falling back to the
+// generic ones for types without their own.
+func Lookup(monitorType models.MonitorType) Builders {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	if builders, ok := registry[monitorType]; ok {
+		return builders
+	}
+	return genericBuilders
+}
+
+func init() {
+	Register(models.TypeHTTP, Builders{BuildEmailHTTPMessage, BuildTelegramHTTPMessage, BuildSMSHTTPMessage})
+	Register(models.TypeDNS, Builders{BuildEmailDNSMessage, BuildTelegramDNSMessage, BuildSMSDNSMessage})
+	Register(models.TypePort, Builders{BuildEmailPortMessage, BuildTelegramPortMessage, BuildSMSPortMessage})
+	Register(models.TypeTLS, Builders{BuildEmailTLSMessage, BuildTelegramTLSMessage, BuildSMSTLSMessage})
+	Register(models.TypeICMP, Builders{BuildEmailICMPMessage, BuildTelegramICMPMessage, BuildSMSICMPMessage})
+	Register(models.TypeUDP, Builders{BuildEmailUDPMessage, BuildTelegramUDPMessage, BuildSMSUDPMessage})
+	Register(models.TypeHeartbeat, Builders{BuildEmailHeartbeatMessage, BuildTelegramHeartbeatMessage, BuildSMSHeartbeatMessage})
+	Register(models.TypeDomain, Builders{BuildEmailDomainMessage, BuildTelegramDomainMessage, BuildSMSDomainMessage})
+
+	database := Builders{BuildEmailDatabaseMessage, BuildTelegramDatabaseMessage, BuildSMSDatabaseMessage}
+	Register(models.TypePostgres, database)
+	Register(models.TypeRedis, database)
+	Register(models.TypeMySQL, database)
+
+	mail := Builders{BuildEmailMailMessage, BuildTelegramMailMessage, BuildSMSMailMessage}
+	Register(models.TypeSMTP, mail)
+	Register(models.TypeIMAP, mail)
+	Register(models.TypePOP3, mail)
+
+	Register(models.TypeGRPC, Builders{BuildEmailGRPCMessage, BuildTelegramGRPCMessage, BuildSMSGRPCMessage})
+	Register(models.TypeWebSocket, Builders{BuildEmailWebSocketMessage, BuildTelegramWebSocketMessage, BuildSMSWebSocketMessage})
+	Register(models.TypeTransaction, Builders{BuildEmailTransactionMessage, BuildTelegramTransactionMessage, BuildSMSTransactionMessage})
+	Register(models.TypeContent, Builders{BuildEmailContentMessage, BuildTelegramContentMessage, BuildSMSContentMessage})
+	Register(models.TypePrometheus, Builders{BuildEmailPrometheusMessage, BuildTelegramPrometheusMessage, BuildSMSPrometheusMessage})
+	Register(models.TypeSSH, Builders{BuildEmailSSHMessage, BuildTelegramSSHMessage, BuildSMSSSHMessage})
+	Register(models.TypeNTP, Builders{BuildEmailNTPMessage, BuildTelegramNTPMessage, BuildSMSNTPMessage})
+	Register(models.TypeDNSBL, Builders{BuildEmailDNSBLMessage, BuildTelegramDNSBLMessage, BuildSMSDNSBLMessage})
+}
@@ -0,0 +1,51 @@
+package templates
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ghduuep/pingly/internal/models"
+)
+
+func TestLookupFallsBackToGeneric(t *testing.T) {
+	got := Lookup(models.MonitorType("unknown"))
+	if reflect.ValueOf(got.Email).Pointer() != reflect.ValueOf(BuildEmailGenericMessage).Pointer() {
+		t.Fatal("unknown type did not fall back to the generic email template")
+	}
+}
+
+func TestLookupRendersEveryType(t *testing.T) {
+	types := []models.MonitorType{
+		models.TypeHTTP, models.TypePort, models.TypeDNS, models.TypeTLS, models.TypeICMP, models.TypeUDP,
+		models.TypeHeartbeat, models.TypeDomain, models.TypePostgres, models.TypeRedis, models.TypeMySQL,
+		models.TypeSMTP, models.TypeIMAP, models.TypePOP3, models.TypeGRPC, models.TypeWebSocket,
+		models.TypeTransaction, models.TypeContent, models.TypePrometheus, models.TypeSSH, models.TypeNTP,
+		models.TypeDNSBL,
+	}
+
+	for _, monitorType := range types {
+		registryMu.RLock()
+		_, ok := registry[monitorType]
+		registryMu.RUnlock()
+		if !ok {
+			t.Errorf("no templates registered for %s", monitorType)
+			continue
+		}
+
+		mon := models.Monitor{ID: 1, Type: monitorType, Target: "example.com"}
+		for _, status := range []models.MonitorStatus{models.StatusUp, models.StatusDown} {
+			res := models.CheckResult{MonitorID: 1, Status: status, Message: "boom"}
+			builders := Lookup(monitorType)
+
+			if subject, body := builders.Email(mon, res, nil); subject == "" || body == "" {
+				t.Errorf("%s %s: empty email", monitorType, status)
+			}
+			if subject, body := builders.Telegram(mon, res, nil); subject == "" || body == "" {
+				t.Errorf("%s %s: empty telegram message", monitorType, status)
+			}
+			if body := builders.SMS(mon, res, nil); body == "" {
+				t.Errorf("%s %s: empty sms", monitorType, status)
+			}
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package templates
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ghduuep/pingly/internal/models"
@@ -30,6 +32,26 @@ func BuildSMSHTTPMessage(m models.Monitor, res models.CheckResult, inc *models.I
 	return msg
 }
 
+// BuildSMSDNSMessage picks the DNS template matching the mode of the monitor
+// and the outcome of the check.
+func BuildSMSDNSMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	var config models.DNSConfig
+	_ = json.Unmarshal(m.Config, &config)
+
+	switch {
+	case config.Mode == "deliverability":
+		return BuildSMSDeliverabilityMessage(m, res, inc)
+	case res.Status == models.StatusUp:
+		return BuildSMSDNSRecoveredMessage(m, res, config.RecordType)
+	case res.Status == models.StatusDown && res.ResultValue != "":
+		return BuildSMSDNSChangedMessage(m, res, config.RecordType)
+	case res.Status == models.StatusDegraded:
+		return BuildSMSDNSWarningMessage(m, res, config.RecordType)
+	default:
+		return BuildSMSDNSStatusMessage(m, res, config.RecordType)
+	}
+}
+
 func BuildSMSDNSRecoveredMessage(m models.Monitor, res models.CheckResult, dnsType string) string {
 	return fmt.Sprintf("PINGLY: [RESOLVED] %s (%s) matches config.", m.Target, dnsType)
 }
@@ -38,10 +60,37 @@ func BuildSMSDNSChangedMessage(m models.Monitor, res models.CheckResult, dnsType
 	return fmt.Sprintf("PINGLY: [ALERT] %s (%s) mismatch. New: %s", m.Target, dnsType, res.ResultValue)
 }
 
+func BuildSMSDNSWarningMessage(m models.Monitor, res models.CheckResult, dnsType string) string {
+	return fmt.Sprintf("PINGLY: [WARN] %s (%s). %s", m.Target, dnsType, res.Message)
+}
+
 func BuildSMSDNSStatusMessage(m models.Monitor, res models.CheckResult, dnsType string) string {
 	return fmt.Sprintf("PINGLY: [FAIL] %s (%s). Err: %s", m.Target, dnsType, res.Message)
 }
 
+func BuildSMSDeliverabilityMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "WEAK"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [MAIL AUTH %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %s", res.ResultValue)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
 func BuildSMSPortMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
 	status := "OK"
 	if res.Status == models.StatusDown {
@@ -64,3 +113,366 @@ func BuildSMSPortMessage(m models.Monitor, res models.CheckResult, inc *models.I
 
 	return msg
 }
+
+func BuildSMSTLSMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "INVALID"
+	} else if res.Status == models.StatusDegraded {
+		status = "EXPIRING"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [TLS %s] %s", status, m.Target)
+
+	if res.Message != "" {
+		msg += fmt.Sprintf(" | %s", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSICMPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "LOSS"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [PING %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSUDPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "SLOW"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [UDP %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSHeartbeatMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "MISSED"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [HEARTBEAT %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSDomainMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "AT RISK"
+	} else if res.Status == models.StatusDegraded {
+		status = "EXPIRING"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [DOMAIN %s] %s", status, m.Target)
+
+	if res.Message != "" {
+		msg += fmt.Sprintf(" | %s", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSDatabaseMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "SLOW"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [%s %s] %s", strings.ToUpper(string(m.Type)), status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSMailMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "SLOW"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [%s %s] %s", strings.ToUpper(string(m.Type)), status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSGRPCMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "SERVING"
+	if res.Status == models.StatusDown {
+		status = "DOWN"
+	} else if res.Status == models.StatusDegraded {
+		status = "UNKNOWN"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [GRPC %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSWebSocketMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "SLOW"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [WS %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSTransactionMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "SLOW"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [TXN %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %s in %dms", res.ResultValue, res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSContentMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	var details models.ContentDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	if details.Diff != "" {
+		return fmt.Sprintf("PINGLY: [CHANGED] %s | +%d/-%d lines", m.Target, details.Added, details.Removed)
+	}
+
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "SLOW"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [CONTENT %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSPrometheusMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "CRIT"
+	} else if res.Status == models.StatusDegraded {
+		status = "WARN"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [METRIC %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | Value: %s", res.ResultValue)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSSSHMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "SLOW"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [SSH %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %s", res.ResultValue)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSNTPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "FAIL"
+	} else if res.Status == models.StatusDegraded {
+		status = "DRIFT"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [NTP %s] %s", status, m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | Offset: %sms", res.ResultValue)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSDNSBLMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	status := "OK"
+	if res.Status == models.StatusDown {
+		status = "LISTED"
+		if res.ResultValue == "" {
+			status = "FAIL"
+		}
+	} else if res.Status == models.StatusDegraded {
+		status = "PARTIAL"
+	}
+
+	msg := fmt.Sprintf("PINGLY: [DNSBL %s] %s", status, m.Target)
+
+	if res.ResultValue != "" {
+		msg += fmt.Sprintf(" | Zones: %s", res.ResultValue)
+	} else if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | %s", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
+
+func BuildSMSGenericMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) string {
+	msg := fmt.Sprintf("PINGLY: [%s %s] %s", strings.ToUpper(string(m.Type)), strings.ToUpper(string(res.Status)), m.Target)
+
+	if res.Status != models.StatusUp {
+		msg += fmt.Sprintf(" | Err: %s", res.Message)
+	} else {
+		msg += fmt.Sprintf(" | %dms", res.Latency)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		msg += fmt.Sprintf(" | Dur: %s", inc.Duration.Round(time.Second))
+	}
+
+	return msg
+}
@@ -1,7 +1,9 @@
 package templates
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ghduuep/pingly/internal/models"
@@ -48,12 +50,32 @@ func BuildTelegramHTTPMessage(m models.Monitor, res models.CheckResult, inc *mod
 	return subject, body
 }
 
+// BuildTelegramDNSMessage picks the DNS template matching the mode of the
+// monitor and the outcome of the check.
+func BuildTelegramDNSMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var config models.DNSConfig
+	_ = json.Unmarshal(m.Config, &config)
+
+	switch {
+	case config.Mode == "deliverability":
+		return BuildTelegramDeliverabilityMessage(m, res, inc)
+	case res.Status == models.StatusUp:
+		return BuildTelegramDNSRecoveredMessage(m, res, config.RecordType, inc)
+	case res.Status == models.StatusDown && res.ResultValue != "":
+		return BuildTelegramDNSChangedMessage(m, res, config.RecordType)
+	case res.Status == models.StatusDegraded:
+		return BuildTelegramDNSWarningMessage(m, res, config.RecordType)
+	default:
+		return BuildTelegramDNSStatusMessage(m, res, config.RecordType)
+	}
+}
+
 func BuildTelegramDNSRecoveredMessage(m models.Monitor, res models.CheckResult, dnsType string, inc *models.Incident) (string, string) {
 	subject := "🟢 Pingly DNS"
 	body := "*DNS INTEGRITY RESTORED*\n\n"
 	body += fmt.Sprintf("🌍 *TARGET*: `%s`\n", m.Target)
 	body += fmt.Sprintf("🏷 *RECORD*: `%s`\n", dnsType)
-	body += fmt.Sprintf("🔢 *VALUE*: `%s`\n", res.ResultValue)
+	body += fmt.Sprintf("🔢 *%s*: `%s`\n", strings.ToUpper(dnsValueLabel(dnsType)), res.ResultValue)
 
 	if inc != nil && inc.Duration != nil {
 		body += fmt.Sprintf("\n⏱ *INSTABILITY*: `%s`", inc.Duration.Round(time.Second))
@@ -65,8 +87,27 @@ func BuildTelegramDNSChangedMessage(m models.Monitor, res models.CheckResult, dn
 	subject := "🚨 Pingly DNS Alert"
 	body := "*RECORD MISMATCH DETECTED*\n\n"
 	body += fmt.Sprintf("Target: `%s` (%s)\n\n", m.Target, dnsType)
-	body += "*NEW VALUE DETECTED*\n"
-	body += fmt.Sprintf("`%s`\n\n", res.ResultValue)
+
+	if disagreeing := disagreeingServers(res); len(disagreeing) > 0 {
+		body += "*DISAGREEING NAMESERVERS*\n"
+		for _, server := range disagreeing {
+			body += fmt.Sprintf("• `%s`\n", server)
+		}
+		body += "\n"
+	} else {
+		body += fmt.Sprintf("*NEW %s DETECTED*\n", strings.ToUpper(dnsValueLabel(dnsType)))
+		body += fmt.Sprintf("`%s`\n\n", res.ResultValue)
+	}
+
+	body += fmt.Sprintf("⚠️ *TRACE*: _%s_", res.Message)
+	return subject, body
+}
+
+func BuildTelegramDNSWarningMessage(m models.Monitor, res models.CheckResult, dnsType string) (string, string) {
+	subject := "🟡 Pingly DNS Warning"
+	body := "*DNS ATTENTION REQUIRED*\n\n"
+	body += fmt.Sprintf("🌍 *TARGET*: `%s` (%s)\n", m.Target, dnsType)
+	body += fmt.Sprintf("🔢 *%s*: `%s`\n", strings.ToUpper(dnsValueLabel(dnsType)), res.ResultValue)
 	body += fmt.Sprintf("⚠️ *TRACE*: _%s_", res.Message)
 	return subject, body
 }
@@ -79,6 +120,38 @@ func BuildTelegramDNSStatusMessage(m models.Monitor, res models.CheckResult, dns
 	return subject, body
 }
 
+func BuildTelegramDeliverabilityMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "EMAIL AUTH BROKEN"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "EMAIL AUTH BELOW POLICY"
+	default:
+		emoji = "🟢"
+		statusLine = "EMAIL AUTH VALID"
+	}
+
+	subject := fmt.Sprintf("%s Pingly Deliverability", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("✉️ *DOMAIN*: `%s`\n", m.Target)
+	body += fmt.Sprintf("🔐 *RECORDS*: `%s`\n", res.ResultValue)
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
 func BuildTelegramPortMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
 	var emoji, statusLine string
 
@@ -109,3 +182,603 @@ func BuildTelegramPortMessage(m models.Monitor, res models.CheckResult, inc *mod
 
 	return subject, body
 }
+
+func BuildTelegramTLSMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "CERTIFICATE INVALID"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "CERTIFICATE EXPIRING"
+	default:
+		emoji = "🟢"
+		statusLine = "CERTIFICATE VALID"
+	}
+
+	var details models.TLSDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	subject := fmt.Sprintf("%s Pingly TLS", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🔒 *HOST*: `%s`\n", m.Target)
+
+	if details.Issuer != "" {
+		body += fmt.Sprintf("🏢 *ISSUER*: `%s`\n", details.Issuer)
+		body += fmt.Sprintf("📅 *EXPIRES*: `%s` (%d days)\n", details.NotAfter.Format("02/01/2006"), details.DaysRemaining)
+	}
+
+	if res.Message != "" {
+		body += fmt.Sprintf("\n📝 *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramICMPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "HOST UNREACHABLE"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "PACKET LOSS"
+	default:
+		emoji = "🟢"
+		statusLine = "HOST REACHABLE"
+	}
+
+	var details models.PingDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	subject := fmt.Sprintf("%s Pingly ICMP", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("📡 *HOST*: `%s`\n", m.Target)
+	body += fmt.Sprintf("📉 *LOSS*: `%.1f%%`\n", details.PacketLoss)
+
+	if details.Received > 0 {
+		body += fmt.Sprintf("⚡ *RTT*: `%.1f/%.1f/%.1f ms`\n", details.MinRTT, details.AvgRTT, details.MaxRTT)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramUDPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "NO RESPONSE"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "HIGH LATENCY"
+	default:
+		emoji = "🟢"
+		statusLine = "RESPONDING"
+	}
+
+	subject := fmt.Sprintf("%s Pingly UDP", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🔌 *HOST*: `%s`\n", m.Target)
+	body += fmt.Sprintf("⚡ *RTT*: `%dms`\n", res.Latency)
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramHeartbeatMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	emoji, statusLine := "🟢", "HEARTBEAT RECEIVED"
+	if res.Status == models.StatusDown {
+		emoji, statusLine = "🔴", "HEARTBEAT MISSED"
+	}
+
+	subject := fmt.Sprintf("%s Pingly Heartbeat", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("💓 *JOB*: `%s`\n", m.Target)
+
+	if res.ResultValue != "" {
+		body += fmt.Sprintf("🕒 *LAST SIGNAL*: `%s`\n", res.ResultValue)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramDomainMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "DOMAIN AT RISK"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "DOMAIN EXPIRING"
+	default:
+		emoji = "🟢"
+		statusLine = "DOMAIN REGISTERED"
+	}
+
+	var details models.DomainDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	subject := fmt.Sprintf("%s Pingly Domain", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🌐 *DOMAIN*: `%s`\n", m.Target)
+
+	if details.Registrar != "" {
+		body += fmt.Sprintf("🏢 *REGISTRAR*: `%s`\n", details.Registrar)
+	}
+
+	if !details.ExpiresAt.IsZero() {
+		body += fmt.Sprintf("📅 *EXPIRES*: `%s` (%d days)\n", details.ExpiresAt.Format("02/01/2006"), details.DaysRemaining)
+	}
+
+	if res.Message != "" {
+		body += fmt.Sprintf("\n📝 *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramDatabaseMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "UNAVAILABLE"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "SLOW QUERY"
+	default:
+		emoji = "🟢"
+		statusLine = "ACCEPTING QUERIES"
+	}
+
+	subject := fmt.Sprintf("%s Pingly %s", emoji, databaseLabel(m.Type))
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🗄 *HOST*: `%s`\n", m.Target)
+	body += fmt.Sprintf("⚡ *QUERY*: `%dms`\n", res.Latency)
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramMailMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "NOT ACCEPTING MAIL"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "HIGH LATENCY"
+	default:
+		emoji = "🟢"
+		statusLine = "READY"
+	}
+
+	subject := fmt.Sprintf("%s Pingly %s", emoji, strings.ToUpper(string(m.Type)))
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("📬 *HOST*: `%s`\n", m.Target)
+	body += fmt.Sprintf("⚡ *RESPONSE*: `%dms`\n", res.Latency)
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramGRPCMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "NOT SERVING"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "HEALTH UNKNOWN"
+	default:
+		emoji = "🟢"
+		statusLine = "SERVING"
+	}
+
+	subject := fmt.Sprintf("%s Pingly gRPC", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🛰 *SERVICE*: `%s`\n", m.Target)
+	body += fmt.Sprintf("⚡ *RESPONSE*: `%dms`\n", res.Latency)
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramWebSocketMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "UNREACHABLE"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "HIGH LATENCY"
+	default:
+		emoji = "🟢"
+		statusLine = "CONNECTED"
+	}
+
+	var details models.WebSocketDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	subject := fmt.Sprintf("%s Pingly WebSocket", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🔗 *URL*: `%s`\n", m.Target)
+	body += fmt.Sprintf("🤝 *HANDSHAKE*: `%dms`\n", res.Latency)
+
+	if details.RoundTrip > 0 {
+		body += fmt.Sprintf("⚡ *RTT*: `%dms`\n", details.RoundTrip)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramTransactionMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "TRANSACTION FAILED"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "SLOW TRANSACTION"
+	default:
+		emoji = "🟢"
+		statusLine = "TRANSACTION PASSING"
+	}
+
+	var details models.TransactionDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	subject := fmt.Sprintf("%s Pingly Transaction", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🔗 *FLOW*: `%s`\n", m.Target)
+	body += fmt.Sprintf("⚡ *TOTAL*: `%dms`\n\n", res.Latency)
+
+	for i, step := range details.Steps {
+		mark := "✅"
+		if step.Error != "" {
+			mark = "❌"
+		}
+
+		name := step.Name
+		if name == "" {
+			name = step.URL
+		}
+
+		body += fmt.Sprintf("%s %d. `%s` (%dms)\n", mark, i+1, name, step.Duration)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n📝 *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramContentMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var details models.ContentDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	if details.Diff != "" {
+		subject := "🟠 Pingly Content Change"
+
+		body := "*PAGE CONTENT CHANGED*\n\n"
+		body += fmt.Sprintf("🔗 *URL*: `%s`\n", m.Target)
+		body += fmt.Sprintf("📝 *CHANGE*: `+%d / -%d lines`\n\n", details.Added, details.Removed)
+		// Backticks would close the code block early.
+		body += fmt.Sprintf("```\n%s\n```", strings.ReplaceAll(details.Diff, "`", "'"))
+
+		return subject, body
+	}
+
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "UNREACHABLE"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "HIGH LATENCY"
+	default:
+		emoji = "🟢"
+		statusLine = "WATCHING"
+	}
+
+	subject := fmt.Sprintf("%s Pingly Content", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🔗 *URL*: `%s`\n", m.Target)
+	body += fmt.Sprintf("⚡ *RESPONSE*: `%dms`\n", res.Latency)
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramPrometheusMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "METRIC CRITICAL"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "METRIC WARNING"
+	default:
+		emoji = "🟢"
+		statusLine = "METRIC HEALTHY"
+	}
+
+	subject := fmt.Sprintf("%s Pingly Metrics", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🔗 *ENDPOINT*: `%s`\n", m.Target)
+
+	if res.ResultValue != "" {
+		body += fmt.Sprintf("📈 *VALUE*: `%s`\n", res.ResultValue)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: `%s`\n", strings.ReplaceAll(res.Message, "`", "'"))
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramSSHMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var details models.SSHDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	var emoji, statusLine string
+
+	switch {
+	case res.Status == models.StatusDown && details.Fingerprint != "":
+		emoji = "🚨"
+		statusLine = "HOST KEY CHANGED"
+	case res.Status == models.StatusDown:
+		emoji = "🔴"
+		statusLine = "SSH UNREACHABLE"
+	case res.Status == models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "SLOW HANDSHAKE"
+	default:
+		emoji = "🟢"
+		statusLine = "HOST KEY VERIFIED"
+	}
+
+	subject := fmt.Sprintf("%s Pingly SSH", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🖥 *HOST*: `%s`\n", m.Target)
+
+	if details.Fingerprint != "" {
+		body += fmt.Sprintf("🔑 *KEY*: `%s %s`\n", details.KeyType, details.Fingerprint)
+	}
+
+	body += fmt.Sprintf("🤝 *HANDSHAKE*: `%dms`\n", res.Latency)
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: `%s`\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramNTPMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "CLOCK FAILURE"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "CLOCK DRIFT"
+	default:
+		emoji = "🟢"
+		statusLine = "CLOCK IN SYNC"
+	}
+
+	var details models.NTPDetails
+	_ = json.Unmarshal(res.Details, &details)
+
+	subject := fmt.Sprintf("%s Pingly NTP", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🕒 *SERVER*: `%s`\n", m.Target)
+
+	if res.ResultValue != "" {
+		body += fmt.Sprintf("⏱ *OFFSET*: `%sms`\n", res.ResultValue)
+		body += fmt.Sprintf("📶 *STRATUM*: `%d`\n", details.Stratum)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramDNSBLMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "BLOCKLISTED"
+		if res.ResultValue == "" {
+			statusLine = "BLOCKLIST CHECK FAILED"
+		}
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "BLOCKLIST CHECK INCOMPLETE"
+	default:
+		emoji = "🟢"
+		statusLine = "NOT BLOCKLISTED"
+	}
+
+	subject := fmt.Sprintf("%s Pingly DNSBL", emoji)
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("📮 *TARGET*: `%s`\n", m.Target)
+
+	if res.ResultValue != "" {
+		body += fmt.Sprintf("🚫 *ZONES*: `%s`\n", res.ResultValue)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}
+
+func BuildTelegramGenericMessage(m models.Monitor, res models.CheckResult, inc *models.Incident) (string, string) {
+	var emoji, statusLine string
+
+	switch res.Status {
+	case models.StatusDown:
+		emoji = "🔴"
+		statusLine = "DOWN"
+	case models.StatusDegraded:
+		emoji = "🟡"
+		statusLine = "DEGRADED"
+	default:
+		emoji = "🟢"
+		statusLine = "UP"
+	}
+
+	subject := fmt.Sprintf("%s Pingly %s", emoji, strings.ToUpper(string(m.Type)))
+
+	body := fmt.Sprintf("*%s*\n\n", statusLine)
+	body += fmt.Sprintf("🎯 *TARGET*: `%s`\n", m.Target)
+	body += fmt.Sprintf("⚡ *RESPONSE*: `%dms`\n", res.Latency)
+
+	if res.ResultValue != "" {
+		body += fmt.Sprintf("🔢 *RESULT*: `%s`\n", res.ResultValue)
+	}
+
+	if res.Status != models.StatusUp {
+		body += fmt.Sprintf("\n❌ *TRACE*: _%s_\n", res.Message)
+	}
+
+	if inc != nil && inc.Duration != nil {
+		body += fmt.Sprintf("\n⏱ *DURATION*: `%s`", inc.Duration.Round(time.Second))
+	}
+
+	return subject, body
+}